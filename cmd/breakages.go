@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/errors"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+	"github.com/fchimpan/gh-workflow-stats/internal/types"
+	"github.com/spf13/cobra"
+)
+
+// breakagesEvent is the event of the runs walked by the breakages command unless --event is given
+const breakagesEvent = "push"
+
+var numBreakages int
+
+var breakagesCmd = &cobra.Command{
+	Use:     "breakages",
+	Short:   "Find the commits that broke a branch: transitions from success to failure of push runs, who pushed them and how long the branch stayed red.",
+	Example: `$ gh workflow-stats breakages --org=OWNER --repo=REPO -f ci.yaml --branch main -c ">=2024-01-01"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolveHost(cmd, &host)
+
+		if err := validateFlags(org, repo, fileName, id); err != nil {
+			return err
+		}
+		if err := validateOutputFlags(documentFormats...); err != nil {
+			return err
+		}
+		if branch == "" {
+			return errors.NewConfigurationError("--branch is required", nil).
+				WithContext("help", "Breakages are transitions between the runs of a single branch, e.g. --branch main")
+		}
+		if event == "" {
+			event = breakagesEvent
+		}
+
+		if numBreakages < 1 {
+			numBreakages = 1
+		}
+
+		cfg := createConfig(host, org, repo, fileName, id)
+		opts := newOptions(numBreakages)
+
+		return breakagesStats(cfg, opts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(breakagesCmd)
+	breakagesCmd.Flags().IntVarP(&numBreakages, "num-breakages", "n", types.DefaultJobCount, "Number of breakages and actors to display")
+}
+
+func breakagesStats(cfg config, opt options) error {
+	sess, err := newSession(cfg, opt, "breakages", workflowRunsText,
+		"branch", opt.branch,
+		"event", opt.event,
+	)
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+	sess.openCache(cfg, opt)
+
+	runs, err := sess.fetchRuns(cfg, opt)
+	if err != nil {
+		return err
+	}
+
+	sess.spinner.Stop()
+
+	bs := parser.WorkflowBreakagesParse(runs, opt.branch, createdUntil(opt.created, time.Now().UTC()))
+
+	return printStats(opt, bs, sess.isRateLimit, func(w io.Writer) {
+		printer.Breakages(w, bs, opt.jobNum)
+	})
+}
+
+// createdUntil returns the upper bound of a --created query, e.g. "2024-01-01..2024-01-31" or "<2024-02-01", capped at now.
+// The zero time is returned when the query has no upper bound, the period then ends at the last run.
+func createdUntil(created string, now time.Time) time.Time {
+	var bound string
+	inclusive := true
+	switch {
+	case strings.Contains(created, ".."):
+		bound = created[strings.Index(created, "..")+2:]
+	case strings.HasPrefix(created, "<="):
+		bound = created[2:]
+	case strings.HasPrefix(created, "<"):
+		bound, inclusive = created[1:], false
+	default:
+		return time.Time{}
+	}
+
+	var until time.Time
+	if t, err := time.Parse(time.DateOnly, bound); err == nil {
+		until = t
+		if inclusive {
+			// A date covers the whole day
+			until = until.AddDate(0, 0, 1)
+		}
+	} else if t, err := time.Parse(time.RFC3339, bound); err == nil {
+		until = t.UTC()
+	} else {
+		return time.Time{}
+	}
+	if until.After(now) {
+		return now
+	}
+	return until
+}
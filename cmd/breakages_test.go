@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatedUntil(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		created string
+		want    time.Time
+	}{
+		{name: "No query", created: "", want: time.Time{}},
+		{name: "Lower bound only", created: ">=2024-01-01", want: time.Time{}},
+		{name: "Open range", created: "2024-01-01..*", want: time.Time{}},
+		{name: "Range of dates", created: "2024-01-01..2024-01-31", want: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "Before a date", created: "<2024-02-01", want: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "Until a date", created: "<=2024-01-31", want: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "Time", created: "<2024-01-31T10:00:00+09:00", want: time.Date(2024, 1, 31, 1, 0, 0, 0, time.UTC)},
+		{name: "Future bound", created: "2024-01-01..2030-01-01", want: now},
+		{name: "Invalid bound", created: "<yesterday", want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, createdUntil(tt.created, now))
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/cache"
+	"github.com/fchimpan/gh-workflow-stats/internal/logger"
+
+	go_github "github.com/google/go-github/v60/github"
+)
+
+// openCache opens the on-disk cache of the workflow. It returns nil when caching is disabled or the cache cannot be opened.
+// The cache is an optimization, so it never prevents fetching from the API.
+func openCache(cfg config, opt options, log logger.Logger) *cache.Store {
+	if opt.noCache {
+		return nil
+	}
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		log.Warn("failed to resolve cache directory, continuing without cache", "error", err)
+		return nil
+	}
+	s, err := cache.Open(dir, cache.Key{
+		Host:     cfg.host,
+		Org:      cfg.org,
+		Repo:     cfg.repo,
+		Workflow: workflowKey(cfg),
+	})
+	if err != nil {
+		log.Warn("failed to open cache, continuing without cache", "error", err)
+		return nil
+	}
+	if opt.refresh {
+		s.Reset()
+	}
+	return s
+}
+
+// saveCache writes the cache to disk. Failures are logged only.
+func saveCache(store *cache.Store, log logger.Logger) {
+	if store == nil {
+		return
+	}
+	if err := store.Save(); err != nil {
+		log.Warn("failed to save cache", "path", store.Path(), "error", err)
+	}
+}
+
+func workflowKey(cfg config) string {
+	if cfg.workflowFileName != "" {
+		return cfg.workflowFileName
+	}
+	return strconv.FormatInt(cfg.workflowID, 10)
+}
+
+// queryKey identifies the set of runs returned by the API for the given filters.
+// Status and created filters are not part of the key as they are not used for cached queries.
+func queryKey(opt options) string {
+	return strings.Join([]string{
+		"actor=" + opt.actor,
+		"branch=" + opt.branch,
+		"event=" + opt.event,
+		"head_sha=" + opt.headSHA,
+		"check_suite_id=" + strconv.FormatInt(opt.checkSuiteID, 10),
+		"exclude_pull_requests=" + strconv.FormatBool(opt.excludePullRequests),
+	}, "&")
+}
+
+// filterCachedRuns applies the API query filters to cached runs
+func filterCachedRuns(runs []*go_github.WorkflowRun, opt options) []*go_github.WorkflowRun {
+	filtered := make([]*go_github.WorkflowRun, 0, len(runs))
+	for _, r := range runs {
+		if opt.actor != "" && r.GetActor().GetLogin() != opt.actor {
+			continue
+		}
+		if opt.branch != "" && r.GetHeadBranch() != opt.branch {
+			continue
+		}
+		if opt.event != "" && r.GetEvent() != opt.event {
+			continue
+		}
+		if opt.headSHA != "" && r.GetHeadSHA() != opt.headSHA {
+			continue
+		}
+		if opt.checkSuiteID != 0 && r.GetCheckSuiteID() != opt.checkSuiteID {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
+
+// rerunWindow is how long after its creation a run can be re-run. A re-run keeps the creation time of the run,
+// so the runs created within the window are fetched again to pick up their new attempts and conclusions.
+const rerunWindow = 30 * 24 * time.Hour
+
+// createdSince returns the created filter of a fetch starting from the watermark, extended back to the re-run window
+func createdSince(wm cache.Watermark, now time.Time) string {
+	since := wm.CreatedAt
+	if w := now.Add(-rerunWindow); w.Before(since) {
+		since = w
+	}
+	return ">=" + since.UTC().Format(time.RFC3339)
+}
+
+// advancesWatermark reports whether a fetch may replace the watermark of its query.
+// Only a fetch with --all is exhaustive, a single page must not advance a watermark that covers the full history.
+func advancesWatermark(current cache.Watermark, ok, all bool) bool {
+	return all || !ok || !current.All
+}
+
+// mergeRuns merges cached and freshly fetched runs. Fetched runs take precedence.
+func mergeRuns(cached, fetched []*go_github.WorkflowRun) []*go_github.WorkflowRun {
+	seen := make(map[string]bool, len(fetched))
+	merged := make([]*go_github.WorkflowRun, 0, len(cached)+len(fetched))
+	for _, r := range fetched {
+		seen[cache.RunKey(r.GetID(), r.GetRunAttempt())] = true
+		merged = append(merged, r)
+	}
+	for _, r := range cached {
+		if seen[cache.RunKey(r.GetID(), r.GetRunAttempt())] {
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// latestRuns keeps the attempts of the n most recently created runs, as a single API page would return
+func latestRuns(runs []*go_github.WorkflowRun, n int) []*go_github.WorkflowRun {
+	created := make(map[int64]go_github.Timestamp)
+	for _, r := range runs {
+		if c, ok := created[r.GetID()]; !ok || r.GetCreatedAt().After(c.Time) {
+			created[r.GetID()] = r.GetCreatedAt()
+		}
+	}
+	if len(created) <= n {
+		return runs
+	}
+
+	ids := make([]int64, 0, len(created))
+	for id := range created {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return created[ids[i]].After(created[ids[j]].Time)
+	})
+	keep := make(map[int64]bool, n)
+	for _, id := range ids[:n] {
+		keep[id] = true
+	}
+
+	res := make([]*go_github.WorkflowRun, 0, len(runs))
+	for _, r := range runs {
+		if keep[r.GetID()] {
+			res = append(res, r)
+		}
+	}
+	return res
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/cache"
+	go_github "github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRun(id int64, attempt int, created time.Time) *go_github.WorkflowRun {
+	return &go_github.WorkflowRun{
+		ID:         go_github.Int64(id),
+		RunAttempt: go_github.Int(attempt),
+		CreatedAt:  &go_github.Timestamp{Time: created},
+		HeadBranch: go_github.String("main"),
+		Event:      go_github.String("push"),
+		Actor:      &go_github.User{Login: go_github.String("octocat")},
+	}
+}
+
+func runIDs(runs []*go_github.WorkflowRun) []int64 {
+	ids := make([]int64, 0, len(runs))
+	for _, r := range runs {
+		ids = append(ids, r.GetID())
+	}
+	return ids
+}
+
+func TestWorkflowKey(t *testing.T) {
+	assert.Equal(t, "ci.yaml", workflowKey(config{workflowFileName: "ci.yaml", workflowID: -1}))
+	assert.Equal(t, "12345", workflowKey(config{workflowID: 12345}))
+}
+
+func TestQueryKey(t *testing.T) {
+	base := options{branch: "main"}
+	assert.Equal(t, queryKey(base), queryKey(options{branch: "main", created: ">2024-01-01", status: []string{"success"}}),
+		"created and status filters do not change the query key")
+	assert.NotEqual(t, queryKey(base), queryKey(options{branch: "develop"}))
+	assert.NotEqual(t, queryKey(base), queryKey(options{branch: "main", actor: "octocat"}))
+}
+
+func TestFilterCachedRuns(t *testing.T) {
+	now := time.Now()
+	other := testRun(2, 1, now)
+	other.HeadBranch = go_github.String("develop")
+	runs := []*go_github.WorkflowRun{testRun(1, 1, now), other}
+
+	tests := []struct {
+		name string
+		opt  options
+		want []int64
+	}{
+		{name: "No filters", opt: options{}, want: []int64{1, 2}},
+		{name: "Branch", opt: options{branch: "develop"}, want: []int64{2}},
+		{name: "Actor", opt: options{actor: "someone"}, want: []int64{}},
+		{name: "Event", opt: options{event: "push"}, want: []int64{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, runIDs(filterCachedRuns(runs, tt.opt)))
+		})
+	}
+}
+
+func TestMergeRuns(t *testing.T) {
+	now := time.Now()
+	stale := testRun(1, 1, now)
+	fresh := testRun(1, 1, now)
+	fresh.Status = go_github.String("completed")
+
+	merged := mergeRuns([]*go_github.WorkflowRun{stale, testRun(2, 1, now)}, []*go_github.WorkflowRun{fresh, testRun(1, 2, now)})
+
+	assert.Len(t, merged, 3)
+	assert.Same(t, fresh, merged[0], "fetched runs take precedence over cached runs")
+}
+
+func TestLatestRuns(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	runs := []*go_github.WorkflowRun{
+		testRun(1, 1, base),
+		testRun(2, 1, base.Add(time.Hour)),
+		testRun(2, 2, base.Add(time.Hour)),
+		testRun(3, 1, base.Add(2*time.Hour)),
+	}
+
+	assert.Equal(t, []int64{2, 2, 3}, runIDs(latestRuns(runs, 2)), "all attempts of the latest runs are kept")
+	assert.Equal(t, []int64{1, 2, 2, 3}, runIDs(latestRuns(runs, 5)))
+}
+
+func TestCreatedSince(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Runs created within the re-run window may have new attempts
+	assert.Equal(t, ">=2024-01-31T12:00:00Z", createdSince(cache.Watermark{CreatedAt: now.Add(-time.Hour)}, now))
+	assert.Equal(t, ">=2024-01-01T00:00:00Z", createdSince(cache.Watermark{CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, now))
+}
+
+func TestAdvancesWatermark(t *testing.T) {
+	tests := []struct {
+		name    string
+		current cache.Watermark
+		ok      bool
+		all     bool
+		want    bool
+	}{
+		{name: "no watermark", want: true},
+		{name: "no watermark with --all", all: true, want: true},
+		{name: "page watermark", current: cache.Watermark{}, ok: true, want: true},
+		{name: "full history watermark with --all", current: cache.Watermark{All: true}, ok: true, all: true, want: true},
+		{name: "full history watermark without --all", current: cache.Watermark{All: true}, ok: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, advancesWatermark(tt.current, tt.ok, tt.all))
+		})
+	}
+}
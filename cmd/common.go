@@ -2,10 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/fchimpan/gh-workflow-stats/internal/errors"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
 	"github.com/fchimpan/gh-workflow-stats/internal/types"
+	go_github "github.com/google/go-github/v60/github"
 	"github.com/spf13/cobra"
 )
 
@@ -13,14 +19,14 @@ import (
 const (
 	ErrMissingOrgRepo  = "--org and --repo flag must be specified. If you want to use GitHub Enterprise Server, specify your GitHub Enterprise Server host with --host flag"
 	ErrMissingWorkflow = "--file or --id flag must be specified"
+	ErrMissingOrg      = "--org flag must be specified"
+	ErrIdenticalSides  = "base and head select the same workflow runs. Set --base-branch/--head-branch or --base-created/--head-created"
 )
 
 // validateFlags validates common flags across commands
 func validateFlags(org, repo, fileName string, id int64) error {
-	if org == "" || repo == "" {
-		return errors.NewConfigurationError(ErrMissingOrgRepo, nil).
-			WithContext("org", org).
-			WithContext("repo", repo)
+	if err := validateRepositoryFlags(org, repo); err != nil {
+		return err
 	}
 	if fileName == "" && id <= 0 {
 		return errors.NewConfigurationError(ErrMissingWorkflow, nil).
@@ -30,6 +36,91 @@ func validateFlags(org, repo, fileName string, id int64) error {
 	return nil
 }
 
+// validateRepositoryFlags validates flags of commands that target a whole repository
+func validateRepositoryFlags(org, repo string) error {
+	if org == "" || repo == "" {
+		return errors.NewConfigurationError(ErrMissingOrgRepo, nil).
+			WithContext("org", org).
+			WithContext("repo", repo)
+	}
+	return nil
+}
+
+// Output formats supported by the commands
+var (
+	documentFormats = []types.OutputFormat{types.OutputFormatText, types.OutputFormatJSON}
+	statsFormats    = slices.Concat(documentFormats, []types.OutputFormat{types.OutputFormatMarkdown, types.OutputFormatHTML, types.OutputFormatOpenMetrics, types.OutputFormatCSV, types.OutputFormatTSV})
+)
+
+// validateOutputFlags validates --format, --json and --table against the formats supported by the command
+func validateOutputFlags(supported ...types.OutputFormat) error {
+	f := types.OutputFormat(format)
+	if js && f != types.OutputFormatText && f != types.OutputFormatJSON {
+		return errors.NewConfigurationError("--json cannot be combined with --format "+format, nil)
+	}
+	if !slices.Contains(supported, f) {
+		return errors.NewConfigurationError(fmt.Sprintf("%s: %q is not supported by this command", types.ErrInvalidOutputFormat, format), nil).
+			WithContext("format", format)
+	}
+	if f.IsTabular() && !types.OutputTable(table).IsValid() {
+		return errors.NewConfigurationError("--table must be one of runs, jobs or steps", nil).
+			WithContext("table", table)
+	}
+	return nil
+}
+
+// validateStatsFlags validates the flags of the workflow runs and jobs stats
+func validateStatsFlags() error {
+	if err := validatePercentiles(percentiles); err != nil {
+		return err
+	}
+	if !parser.IsValidDurationMode(durationMode) {
+		return errors.NewConfigurationError("--duration-mode must be one of updated or jobs", nil).
+			WithContext("duration_mode", durationMode)
+	}
+	if attempts != "" && !parser.IsValidAttempts(attempts) {
+		return errors.NewConfigurationError("--attempts must be one of first, last or all", nil).
+			WithContext("attempts", attempts)
+	}
+	if histogram && buckets < 1 {
+		return errors.NewConfigurationError("--buckets must be at least 1", nil).
+			WithContext("buckets", strconv.Itoa(buckets))
+	}
+	return nil
+}
+
+// validateGroupBy validates the dimensions of --group-by
+func validateGroupBy(dimensions []string) error {
+	for _, d := range dimensions {
+		if !parser.IsValidGroupBy(d) {
+			return errors.NewConfigurationError("--group-by must be any of job, runner-label, runner-group and runner-name", nil).
+				WithContext("group_by", d)
+		}
+	}
+	return nil
+}
+
+// validatePercentiles validates that every percentile of --percentiles is between 0 and 100
+func validatePercentiles(ps []float64) error {
+	for _, p := range ps {
+		if p < 0 || p > 100 || math.IsNaN(p) {
+			return errors.NewConfigurationError("--percentiles must be between 0 and 100", nil).
+				WithContext("percentile", strconv.FormatFloat(p, 'f', -1, 64))
+		}
+	}
+	return nil
+}
+
+// loadTimeZone loads the IANA time zone of --timezone
+func loadTimeZone(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.NewConfigurationError(fmt.Sprintf("invalid time zone %q", name), err).
+			WithContext("timezone", name)
+	}
+	return loc, nil
+}
+
 // resolveHost resolves the host from environment variable if not set via flag
 func resolveHost(cmd *cobra.Command, host *string) {
 	if envHost := os.Getenv("GH_HOST"); envHost != "" && !cmd.Flags().Changed("host") {
@@ -37,6 +128,46 @@ func resolveHost(cmd *cobra.Command, host *string) {
 	}
 }
 
+// newOptions creates options from the command flags
+func newOptions(jobNum int) options {
+	opts := createOptions(actor, branch, event, status, created, headSHA,
+		excludePullRequests, all, js, checkSuiteID, jobNum)
+	opts.noCache = noCache
+	opts.refresh = refresh
+	opts.format = types.OutputFormat(format)
+	if js {
+		opts.format = types.OutputFormatJSON
+	}
+	opts.js = opts.format == types.OutputFormatJSON
+	opts.table = types.OutputTable(table)
+	opts.percentiles = percentiles
+	opts.durationMode = durationMode
+	opts.attempts = attempts
+	if histogram {
+		opts.histogramBuckets = buckets
+	}
+	return opts
+}
+
+// parseOptions returns the options of the stats parsers. jobs are the jobs of the workflow runs, if fetched.
+func parseOptions(opt options, jobs []*go_github.WorkflowJob) parser.ParseOptions {
+	return parser.ParseOptions{
+		Percentiles:      opt.percentiles,
+		DurationMode:     opt.durationMode,
+		Jobs:             jobs,
+		Attempts:         opt.attempts,
+		GroupBy:          opt.groupBy,
+		Matrix:           opt.matrix,
+		MatrixAxis:       opt.matrixAxis,
+		HistogramBuckets: opt.histogramBuckets,
+	}
+}
+
+// needsJobs reports whether the workflow runs stats need the jobs of the runs
+func needsJobs(opt options) bool {
+	return opt.durationMode == parser.DurationModeJobs
+}
+
 // Legacy functions for backward compatibility
 // These will be removed in a future version
 
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"math"
 	"os"
 	"testing"
 
@@ -104,6 +105,44 @@ func TestValidateFlags(t *testing.T) {
 	}
 }
 
+func TestValidateRepositoryFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		org     string
+		repo    string
+		wantErr bool
+	}{
+		{
+			name: "Valid flags",
+			org:  "test-org",
+			repo: "test-repo",
+		},
+		{
+			name:    "Missing org",
+			repo:    "test-repo",
+			wantErr: true,
+		},
+		{
+			name:    "Missing repo",
+			org:     "test-org",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRepositoryFlags(tt.org, tt.repo)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, "ConfigurationError: "+ErrMissingOrgRepo, err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestResolveHost(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -281,3 +320,137 @@ func TestConstants(t *testing.T) {
 	assert.Equal(t, "--file or --id flag must be specified", ErrMissingWorkflow)
 	assert.Equal(t, 3, types.DefaultJobCount)
 }
+
+func TestValidateOutputFlags(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		table     string
+		js        bool
+		supported []types.OutputFormat
+		wantErr   bool
+	}{
+		{name: "Text", format: "text", table: "runs", supported: documentFormats},
+		{name: "JSON flag", format: "text", table: "runs", js: true, supported: documentFormats},
+		{name: "JSON flag and format", format: "json", table: "runs", js: true, supported: documentFormats},
+		{name: "JSON flag and CSV format", format: "csv", table: "runs", js: true, supported: statsFormats, wantErr: true},
+		{name: "CSV", format: "csv", table: "steps", supported: statsFormats},
+		{name: "CSV not supported", format: "csv", table: "runs", supported: documentFormats, wantErr: true},
+		{name: "Unknown format", format: "xml", table: "runs", supported: statsFormats, wantErr: true},
+		{name: "Unknown table", format: "tsv", table: "artifacts", supported: statsFormats, wantErr: true},
+		{name: "Table is ignored by text", format: "text", table: "artifacts", supported: statsFormats},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origFormat, origTable, origJS := format, table, js
+			t.Cleanup(func() { format, table, js = origFormat, origTable, origJS })
+			format, table, js = tt.format, tt.table, tt.js
+
+			err := validateOutputFlags(tt.supported...)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidatePercentiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		percentiles []float64
+		wantErr     bool
+	}{
+		{name: "none", percentiles: nil},
+		{name: "valid", percentiles: []float64{0, 50, 99.9, 100}},
+		{name: "negative", percentiles: []float64{50, -1}, wantErr: true},
+		{name: "above 100", percentiles: []float64{101}, wantErr: true},
+		{name: "NaN", percentiles: []float64{math.NaN()}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePercentiles(tt.percentiles)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateStatsFlags(t *testing.T) {
+	origPercentiles, origDurationMode, origAttempts := percentiles, durationMode, attempts
+	t.Cleanup(func() { percentiles, durationMode, attempts = origPercentiles, origDurationMode, origAttempts })
+
+	percentiles, durationMode = []float64{95}, "jobs"
+	assert.NoError(t, validateStatsFlags())
+
+	durationMode = "wall"
+	assert.Error(t, validateStatsFlags())
+
+	percentiles, durationMode = []float64{150}, "updated"
+	assert.Error(t, validateStatsFlags())
+
+	percentiles, attempts = []float64{95}, "last"
+	assert.NoError(t, validateStatsFlags())
+
+	attempts = "latest"
+	assert.Error(t, validateStatsFlags())
+
+	origHistogram, origBuckets := histogram, buckets
+	t.Cleanup(func() { histogram, buckets = origHistogram, origBuckets })
+	attempts, histogram, buckets = "", false, 0
+	assert.NoError(t, validateStatsFlags())
+
+	histogram = true
+	assert.Error(t, validateStatsFlags())
+}
+
+func TestNewOptions_Histogram(t *testing.T) {
+	origHistogram, origBuckets := histogram, buckets
+	t.Cleanup(func() { histogram, buckets = origHistogram, origBuckets })
+
+	histogram, buckets = false, 20
+	assert.Equal(t, 0, parseOptions(newOptions(0), nil).HistogramBuckets)
+
+	histogram = true
+	assert.Equal(t, 20, parseOptions(newOptions(0), nil).HistogramBuckets)
+}
+
+func TestNeedsJobs(t *testing.T) {
+	assert.False(t, needsJobs(options{durationMode: "updated"}))
+	assert.True(t, needsJobs(options{durationMode: "jobs"}))
+}
+
+func TestLoadTimeZone(t *testing.T) {
+	loc, err := loadTimeZone("Asia/Tokyo")
+	assert.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", loc.String())
+
+	_, err = loadTimeZone("Mars/Olympus")
+	assert.Error(t, err)
+}
+
+func TestNewOptions_Format(t *testing.T) {
+	origFormat, origTable, origJS := format, table, js
+	t.Cleanup(func() { format, table, js = origFormat, origTable, origJS })
+
+	format, table, js = "text", "runs", true
+	opts := newOptions(0)
+	assert.Equal(t, types.OutputFormatJSON, opts.format)
+	assert.True(t, opts.js)
+
+	format, table, js = "tsv", "jobs", false
+	opts = newOptions(0)
+	assert.Equal(t, types.OutputFormatTSV, opts.format)
+	assert.Equal(t, types.OutputTableJobs, opts.table)
+	assert.False(t, opts.js)
+}
+
+func TestValidateGroupBy(t *testing.T) {
+	assert.NoError(t, validateGroupBy(nil))
+	assert.NoError(t, validateGroupBy([]string{"job", "runner-name"}))
+	assert.Error(t, validateGroupBy([]string{"runner"}))
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/cache"
+	"github.com/fchimpan/gh-workflow-stats/internal/errors"
+	"github.com/fchimpan/gh-workflow-stats/internal/github"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	baseBranch  string
+	headBranch  string
+	baseCreated string
+	headCreated string
+	alpha       float64
+)
+
+var compareCmd = &cobra.Command{
+	Use:     "compare",
+	Short:   "Compare workflow, job and step stats of two branches or time windows. Report significant changes of the success rate and execution time.",
+	Example: `$ gh workflow-stats compare --org=OWNER --repo=REPO -f ci.yaml --base-created="2024-01-01..2024-01-31" --head-created=">=2024-02-01"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolveHost(cmd, &host)
+
+		if err := validateFlags(org, repo, fileName, id); err != nil {
+			return err
+		}
+		if err := validateOutputFlags(documentFormats...); err != nil {
+			return err
+		}
+		if alpha <= 0 || alpha >= 1 {
+			return errors.NewConfigurationError("--alpha must be between 0 and 1", nil).
+				WithContext("alpha", fmt.Sprintf("%g", alpha))
+		}
+
+		cfg := createConfig(host, org, repo, fileName, id)
+		opts := newOptions(0)
+		base := sideOptions(opts, baseBranch, baseCreated)
+		head := sideOptions(opts, headBranch, headCreated)
+		if sideLabel(base) == sideLabel(head) {
+			return errors.NewConfigurationError(ErrIdenticalSides, nil)
+		}
+
+		return compareStats(cfg, base, head, alpha)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+	compareCmd.Flags().StringVar(&baseBranch, "base-branch", "", "Branch of the base workflow runs. Defaults to --branch")
+	compareCmd.Flags().StringVar(&headBranch, "head-branch", "", "Branch of the head workflow runs. Defaults to --branch")
+	compareCmd.Flags().StringVar(&baseCreated, "base-created", "", "createdAt range of the base workflow runs. Defaults to --created")
+	compareCmd.Flags().StringVar(&headCreated, "head-created", "", "createdAt range of the head workflow runs. Defaults to --created")
+	compareCmd.Flags().Float64Var(&alpha, "alpha", parser.DefaultSignificanceLevel, "Significance level. Changes with a p-value below it are flagged as significant")
+}
+
+// sideOptions overrides the branch and created filters of one side of the comparison
+func sideOptions(opt options, branch, created string) options {
+	if branch != "" {
+		opt.branch = branch
+	}
+	if created != "" {
+		opt.created = created
+	}
+	return opt
+}
+
+// sideLabel describes the workflow runs selected by one side of the comparison
+func sideLabel(opt options) string {
+	parts := []string{}
+	if opt.branch != "" {
+		parts = append(parts, "branch="+opt.branch)
+	}
+	if opt.created != "" {
+		parts = append(parts, "created="+opt.created)
+	}
+	if len(parts) == 0 {
+		return "all runs"
+	}
+	return strings.Join(parts, " ")
+}
+
+func compareStats(cfg config, base, head options, alpha float64) error {
+	sess, err := newSession(cfg, base, "compare", workflowRunsText,
+		"base", sideLabel(base),
+		"head", sideLabel(head),
+	)
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+	sess.openCache(cfg, base)
+
+	inputs := make([]parser.ComparisonInput, 0, 2)
+	for _, opt := range []options{base, head} {
+		label := sideLabel(opt)
+		sess.progress(fmt.Sprintf("  fetching workflow runs and jobs of %s...", label), "green")
+		in, err := fetchComparisonInput(sess.ctx, sess.client, sess.store, cfg, opt)
+		if err := sess.check(err); err != nil {
+			return err
+		}
+		in.Label = label
+		inputs = append(inputs, in)
+	}
+
+	sess.spinner.Stop()
+
+	cs := parser.WorkflowCompareParse(inputs[0], inputs[1], alpha)
+
+	return printStats(base, cs, sess.isRateLimit, func(w io.Writer) {
+		printer.Compare(w, cs)
+	})
+}
+
+// fetchComparisonInput fetches the workflow runs of one side of the comparison and their jobs.
+// Partial results are returned along with a rate limit error.
+func fetchComparisonInput(ctx context.Context, client *github.WorkflowStatsClient, store *cache.Store, cfg config, opt options) (parser.ComparisonInput, error) {
+	runs, err := fetchWorkflowRuns(ctx, client, store, cfg, opt)
+	if err != nil {
+		return parser.ComparisonInput{Runs: runs}, err
+	}
+	jobs, err := fetchWorkflowJobs(ctx, client, store, cfg, runs)
+	return parser.ComparisonInput{Runs: runs, Jobs: jobs}, err
+}
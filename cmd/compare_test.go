@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSideOptions(t *testing.T) {
+	opt := options{branch: "main", created: ">=2024-01-01", actor: "octocat"}
+
+	assert.Equal(t, opt, sideOptions(opt, "", ""))
+
+	got := sideOptions(opt, "feature", "")
+	assert.Equal(t, "feature", got.branch)
+	assert.Equal(t, ">=2024-01-01", got.created)
+	assert.Equal(t, "octocat", got.actor)
+	assert.Equal(t, "main", opt.branch)
+}
+
+func TestSideLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  options
+		want string
+	}{
+		{name: "No filter", opt: options{}, want: "all runs"},
+		{name: "Branch", opt: options{branch: "main"}, want: "branch=main"},
+		{name: "Branch and created", opt: options{branch: "main", created: "<2024-01-01"}, want: "branch=main created=<2024-01-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sideLabel(tt.opt))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/errors"
+	"github.com/fchimpan/gh-workflow-stats/internal/github"
+	"github.com/fchimpan/gh-workflow-stats/internal/logger"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+	"github.com/spf13/cobra"
+
+	go_github "github.com/google/go-github/v60/github"
+)
+
+var priceTableFile string
+
+var costCmd = &cobra.Command{
+	Use:   "cost",
+	Short: "Estimate the billable minutes and the cost of workflow runs by workflow, job and runner. Without --file or --id, every workflow in the repository is included.",
+	Example: `$ gh workflow-stats cost --org=OWNER --repo=REPO -f ci.yaml --created=">=2024-01-01"
+$ gh workflow-stats cost --org=OWNER --repo=REPO --price-table=prices.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolveHost(cmd, &host)
+
+		if err := validateRepositoryFlags(org, repo); err != nil {
+			return err
+		}
+		if err := validateOutputFlags(documentFormats...); err != nil {
+			return err
+		}
+		pt, err := loadPriceTable(priceTableFile)
+		if err != nil {
+			return err
+		}
+
+		cfg := createConfig(host, org, repo, fileName, id)
+		opts := newOptions(0)
+
+		return costStats(cfg, opts, pt)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(costCmd)
+	costCmd.Flags().StringVar(&priceTableFile, "price-table", "", `JSON file of runner prices keyed by runner label, merged into the default GitHub-hosted prices.
+ e.g. {"ubuntu-latest-16-cores": {"multiplier": 1, "price_per_minute": 0.064}, "self-hosted": {"price_per_minute": 0.002}}`)
+}
+
+// loadPriceTable reads the price table file, or returns the default price table when no file is given
+func loadPriceTable(path string) (parser.PriceTable, error) {
+	if path == "" {
+		return parser.DefaultPriceTable(), nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.NewConfigurationError("failed to open the price table", err).
+			WithContext("price_table", path)
+	}
+	defer func() { _ = f.Close() }()
+
+	pt, err := parser.ReadPriceTable(f)
+	if err != nil {
+		return nil, errors.NewConfigurationError("invalid price table", err).
+			WithContext("price_table", path)
+	}
+	return pt, nil
+}
+
+func costStats(cfg config, opt options, pt parser.PriceTable) error {
+	sess, err := newSession(cfg, opt, "cost", workflowRunsText)
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+
+	inputs, isRateLimit, err := fetchCostInputs(sess.ctx, sess.client, cfg, opt, sess.log, func(name string) {
+		sess.progress(fmt.Sprintf("  fetching workflow runs and jobs of %s...", name), "green")
+	})
+	if err != nil {
+		return err
+	}
+
+	sess.spinner.Stop()
+
+	cs := parser.WorkflowCostParse(inputs, pt)
+	cs.RateLimited = isRateLimit
+
+	return printStats(opt, cs, isRateLimit, func(w io.Writer) {
+		printer.Cost(w, cs)
+	})
+}
+
+// fetchCostInputs fetches the runs and jobs of the workflow, or of every workflow in the repository when no workflow is given.
+// When the rate limit is reached, the workflows fetched so far are returned.
+func fetchCostInputs(ctx context.Context, client *github.WorkflowStatsClient, cfg config, opt options, log logger.Logger, progress func(string)) ([]parser.CostInput, bool, error) {
+	cfgs := []config{cfg}
+	names := []string{workflowKey(cfg)}
+	if cfg.workflowFileName == "" && cfg.workflowID <= 0 {
+		workflows, err := client.FetchWorkflows(ctx, cfg.org, cfg.repo)
+		if err != nil {
+			if isRateLimitError(err) {
+				return nil, true, nil
+			}
+			return nil, false, err
+		}
+		cfgs, names = cfgs[:0], names[:0]
+		for _, wf := range workflows {
+			cfgs = append(cfgs, workflowConfig(cfg, wf))
+			names = append(names, wf.GetName())
+		}
+	}
+
+	inputs := make([]parser.CostInput, 0, len(cfgs))
+	for i, wcfg := range cfgs {
+		progress(names[i])
+
+		store := openCache(wcfg, opt, log)
+		runs, err := fetchWorkflowRuns(ctx, client, store, wcfg, opt)
+		var jobs []*go_github.WorkflowJob
+		if err == nil {
+			jobs, err = fetchWorkflowJobs(ctx, client, store, wcfg, runs)
+		}
+		saveCache(store, log)
+
+		name := names[i]
+		if len(runs) > 0 && runs[0].GetName() != "" {
+			name = runs[0].GetName()
+		}
+		inputs = append(inputs, parser.CostInput{Workflow: name, Runs: runs, Jobs: jobs})
+		if err != nil {
+			if !isRateLimitError(err) {
+				return nil, false, err
+			}
+			// The rate limit is shared by all workflows, so the remaining workflows cannot be fetched either
+			log.Warn("rate limit reached, skipping remaining workflows", "workflow", name)
+			return inputs, true, nil
+		}
+	}
+	return inputs, false, nil
+}
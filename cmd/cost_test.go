@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPriceTable(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "prices.json")
+	require.NoError(t, os.WriteFile(valid, []byte(`{"gpu": {"multiplier": 1, "price_per_minute": 0.07}}`), 0o600))
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"gpu": 1}`), 0o600))
+
+	pt, err := loadPriceTable("")
+	require.NoError(t, err)
+	assert.Equal(t, parser.DefaultPriceTable(), pt)
+
+	pt, err = loadPriceTable(valid)
+	require.NoError(t, err)
+	assert.Equal(t, parser.RunnerPrice{Multiplier: 1, PricePerMinute: 0.07}, pt["gpu"])
+	assert.Contains(t, pt, parser.RunnerLinux)
+
+	_, err = loadPriceTable(invalid)
+	assert.Error(t, err)
+
+	_, err = loadPriceTable(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+	"github.com/fchimpan/gh-workflow-stats/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var numCriticalJobs int
+
+var criticalPathCmd = &cobra.Command{
+	Use:     "critical-path",
+	Short:   "Find the jobs that determine the wall-clock time of runs: how often every job sits on the critical path of a successful run and how much time it contributes.",
+	Example: `$ gh workflow-stats critical-path --org=OWNER --repo=REPO -f ci.yaml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolveHost(cmd, &host)
+
+		if err := validateFlags(org, repo, fileName, id); err != nil {
+			return err
+		}
+		if err := validateOutputFlags(documentFormats...); err != nil {
+			return err
+		}
+		if err := validateStatsFlags(); err != nil {
+			return err
+		}
+
+		if numCriticalJobs < 1 {
+			numCriticalJobs = 1
+		}
+
+		cfg := createConfig(host, org, repo, fileName, id)
+		opts := newOptions(numCriticalJobs)
+
+		return criticalPathStats(cfg, opts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(criticalPathCmd)
+	criticalPathCmd.Flags().IntVarP(&numCriticalJobs, "num-jobs", "n", types.DefaultJobCount, "Number of jobs to display")
+}
+
+func criticalPathStats(cfg config, opt options) error {
+	sess, err := newSession(cfg, opt, "critical path", workflowRunsText)
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+	sess.openCache(cfg, opt)
+
+	runs, err := sess.fetchRuns(cfg, opt)
+	if err != nil {
+		return err
+	}
+	jobs, err := sess.fetchJobs(cfg, runs)
+	if err != nil {
+		return err
+	}
+
+	sess.spinner.Stop()
+
+	cs := parser.WorkflowCriticalPathParse(runs, jobs, parseOptions(opt, nil))
+
+	return printStats(opt, cs, sess.isRateLimit, func(w io.Writer) {
+		printer.CriticalPath(w, cs, opt.jobNum)
+	})
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/github"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+	"github.com/fchimpan/gh-workflow-stats/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var numFailureSignatures int
+
+var failuresCmd = &cobra.Command{
+	Use:     "failures",
+	Short:   "Group failed jobs by signature: job name, failed step and check run annotation message. See whether failures are one breakage or many unrelated ones.",
+	Example: `$ gh workflow-stats failures --org=OWNER --repo=REPO -f ci.yaml -c ">=2024-01-01"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolveHost(cmd, &host)
+
+		if err := validateFlags(org, repo, fileName, id); err != nil {
+			return err
+		}
+		if err := validateOutputFlags(documentFormats...); err != nil {
+			return err
+		}
+
+		if numFailureSignatures < 1 {
+			numFailureSignatures = 1
+		}
+
+		cfg := createConfig(host, org, repo, fileName, id)
+		opts := newOptions(numFailureSignatures)
+
+		return failuresStats(cfg, opts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(failuresCmd)
+	failuresCmd.Flags().IntVarP(&numFailureSignatures, "num-signatures", "n", types.DefaultJobCount, "Number of failure signatures to display")
+}
+
+func failuresStats(cfg config, opt options) error {
+	sess, err := newSession(cfg, opt, "failures", workflowRunsText)
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+	sess.openCache(cfg, opt)
+
+	runs, err := sess.fetchRuns(cfg, opt)
+	if err != nil {
+		return err
+	}
+	jobs, err := sess.fetchJobs(cfg, runs)
+	if err != nil {
+		return err
+	}
+
+	sess.progress(jobAnnotationsText, "red")
+	annotations, err := sess.client.FetchJobAnnotations(sess.ctx, &github.WorkflowRunsConfig{Org: cfg.org, Repo: cfg.repo}, failedJobs(jobs))
+	if err := sess.check(err); err != nil {
+		return err
+	}
+
+	sess.spinner.Stop()
+
+	fs := parser.WorkflowFailureSignaturesParse(jobs, annotations)
+
+	return printStats(opt, fs, sess.isRateLimit, func(w io.Writer) {
+		printer.FailureSignatures(w, fs, opt.jobNum)
+	})
+}
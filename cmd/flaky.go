@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+	"github.com/fchimpan/gh-workflow-stats/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var numFlakyJobs int
+
+var flakyCmd = &cobra.Command{
+	Use:     "flaky",
+	Short:   "Detect flaky jobs and steps: jobs that failed on an attempt of a run and succeeded on a retry of the same commit.",
+	Example: `$ gh workflow-stats flaky --org=OWNER --repo=REPO -f ci.yaml -A`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolveHost(cmd, &host)
+
+		if err := validateFlags(org, repo, fileName, id); err != nil {
+			return err
+		}
+		if err := validateOutputFlags(documentFormats...); err != nil {
+			return err
+		}
+
+		if numFlakyJobs < 1 {
+			numFlakyJobs = 1
+		}
+
+		cfg := createConfig(host, org, repo, fileName, id)
+		opts := newOptions(numFlakyJobs)
+
+		return flakyStats(cfg, opts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(flakyCmd)
+	flakyCmd.Flags().IntVarP(&numFlakyJobs, "num-jobs", "n", types.DefaultJobCount, "Number of jobs to display")
+}
+
+func flakyStats(cfg config, opt options) error {
+	sess, err := newSession(cfg, opt, "flaky", workflowRunsText)
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+	sess.openCache(cfg, opt)
+
+	runs, err := sess.fetchRuns(cfg, opt)
+	if err != nil {
+		return err
+	}
+	jobs, err := sess.fetchJobs(cfg, runs)
+	if err != nil {
+		return err
+	}
+
+	sess.spinner.Stop()
+
+	fs := parser.WorkflowFlakyParse(jobs)
+
+	return printStats(opt, fs, sess.isRateLimit, func(w io.Writer) {
+		printer.Flaky(w, fs, opt.jobNum)
+	})
+}
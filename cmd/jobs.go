@@ -1,12 +1,19 @@
 package cmd
 
 import (
+	"github.com/fchimpan/gh-workflow-stats/internal/errors"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
 	"github.com/fchimpan/gh-workflow-stats/internal/types"
 	"github.com/spf13/cobra"
 )
 
 var (
-	numJobs int
+	numJobs         int
+	showQueue       bool
+	showFailureLogs bool
+	groupBy         []string
+	matrix          bool
+	matrixAxis      string
 )
 
 var jobsCmd = &cobra.Command{
@@ -19,14 +26,37 @@ var jobsCmd = &cobra.Command{
 		if err := validateFlags(org, repo, fileName, id); err != nil {
 			return err
 		}
+		if err := validateOutputFlags(statsFormats...); err != nil {
+			return err
+		}
+		if err := validateStatsFlags(); err != nil {
+			return err
+		}
+		if err := validateGroupBy(groupBy); err != nil {
+			return err
+		}
+		if !parser.IsValidMatrixAxis(matrixAxis) {
+			return errors.NewConfigurationError("--matrix-axis must be a valid glob pattern", nil).
+				WithContext("matrix_axis", matrixAxis)
+		}
 
 		if numJobs < 1 {
 			numJobs = 1
 		}
 
+		loc, err := loadTimeZone(timeZone)
+		if err != nil {
+			return err
+		}
+
 		cfg := createConfig(host, org, repo, fileName, id)
-		opts := createOptions(actor, branch, event, status, created, headSHA,
-			excludePullRequests, all, js, checkSuiteID, numJobs)
+		opts := newOptions(numJobs)
+		opts.queue = showQueue
+		opts.failureLogs = showFailureLogs
+		opts.groupBy = groupBy
+		opts.matrix = matrix
+		opts.matrixAxis = matrixAxis
+		opts.timeZone = loc
 
 		return workflowStats(cfg, opts, true)
 	},
@@ -35,4 +65,13 @@ var jobsCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(jobsCmd)
 	jobsCmd.Flags().IntVarP(&numJobs, "num-jobs", "n", types.DefaultJobCount, "Number of jobs to display")
+	jobsCmd.Flags().BoolVar(&showQueue, "queue", false, "Show the time jobs waited for a runner, overall, per runner label and per hour of the day")
+	jobsCmd.Flags().BoolVar(&showFailureLogs, "failure-logs", false, "Download the logs of the failed jobs and cluster the failures by error message. Note the GitHub API rate limit.")
+	jobsCmd.Flags().StringSliceVar(&groupBy, "group-by", []string{parser.GroupByJob}, "Dimensions the jobs stats are grouped by. Any of job, runner-label, runner-group and runner-name. e.g. job,runner-name")
+	jobsCmd.Flags().BoolVar(&matrix, "matrix", false, "Roll the matrix legs of a job, e.g. \"build (ubuntu-latest)\", up under their base job \"build\" and break them down per leg")
+	jobsCmd.Flags().StringVar(&matrixAxis, "matrix-axis", "", "Pivot the jobs stats by the matrix axis values matching the glob pattern and break them down per base job. e.g. \"windows-*\"")
+	jobsCmd.Flags().StringVar(&timeZone, "timezone", "UTC", "IANA time zone of the hours of the day of --queue. e.g. Asia/Tokyo")
+	addDurationModeFlag(jobsCmd)
+	addAttemptsFlag(jobsCmd)
+	addHistogramFlags(jobsCmd)
 }
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/github"
+	"github.com/fchimpan/gh-workflow-stats/internal/logger"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+
+	go_github "github.com/google/go-github/v60/github"
+)
+
+// newWorkflowMetrics labels the stats of the workflow for the OpenMetrics output
+func newWorkflowMetrics(cfg config, wrs *parser.WorkflowRunsStatsSummary, jobs []*parser.WorkflowJobsStatsSummary, rateLimited bool) *printer.WorkflowMetrics {
+	name := wrs.Name
+	if name == "" {
+		name = workflowKey(cfg)
+	}
+	return &printer.WorkflowMetrics{
+		Repository:  cfg.org + "/" + cfg.repo,
+		Workflow:    name,
+		RateLimited: rateLimited,
+		Runs:        wrs,
+		Jobs:        jobs,
+	}
+}
+
+// collectWorkflowMetrics fetches the runs of the workflow, and their jobs if withJobs is set.
+// Reaching the rate limit is not an error: the partial results are flagged as rate limited.
+func collectWorkflowMetrics(ctx context.Context, client *github.WorkflowStatsClient, cfg config, opt options, withJobs bool, log logger.Logger) (*printer.WorkflowMetrics, error) {
+	store := openCache(cfg, opt, log)
+	defer saveCache(store, log)
+
+	isRateLimit := false
+	runs, err := fetchWorkflowRuns(ctx, client, store, cfg, opt)
+	if err != nil {
+		if !isRateLimitError(err) {
+			return nil, err
+		}
+		isRateLimit = true
+	}
+
+	var rawJobs []*go_github.WorkflowJob
+	var jobs []*parser.WorkflowJobsStatsSummary
+	if (withJobs || needsJobs(opt)) && !isRateLimit {
+		j, err := fetchWorkflowJobs(ctx, client, store, cfg, runs)
+		if err != nil {
+			if !isRateLimitError(err) {
+				return nil, err
+			}
+			isRateLimit = true
+		}
+		rawJobs = j
+		if withJobs {
+			jobs = parser.WorkflowJobsParseWithOptions(j, parseOptions(opt, nil))
+		}
+	}
+
+	return newWorkflowMetrics(cfg, parser.WorkflowRunsParseWithOptions(runs, parseOptions(opt, rawJobs)), jobs, isRateLimit), nil
+}
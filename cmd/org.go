@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/concurrency"
+	"github.com/fchimpan/gh-workflow-stats/internal/errors"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+	"github.com/spf13/cobra"
+
+	go_github "github.com/google/go-github/v60/github"
+)
+
+const (
+	repositoriesText  = "  fetching repositories..."
+	defaultOrgWorkers = 3
+)
+
+var (
+	includeRepos   []string
+	excludeRepos   []string
+	topics         []string
+	orgConcurrency int
+)
+
+var orgCmd = &cobra.Command{
+	Use:     "org",
+	Short:   "Fetch stats of every workflow in the repositories of an organization.",
+	Example: `$ gh workflow-stats org --org=OWNER --include="service-*" --exclude="*-archive" --topic=backend`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolveHost(cmd, &host)
+
+		if org == "" {
+			return errors.NewConfigurationError(ErrMissingOrg, nil).WithContext("org", org)
+		}
+		if err := validateOutputFlags(documentFormats...); err != nil {
+			return err
+		}
+		for _, p := range append(slices.Clone(includeRepos), excludeRepos...) {
+			if _, err := path.Match(p, ""); err != nil {
+				return errors.NewConfigurationError(fmt.Sprintf("invalid repository pattern %q", p), err)
+			}
+		}
+		if orgConcurrency < 1 {
+			orgConcurrency = 1
+		}
+
+		cfg := createConfig(host, org, "", "", -1)
+		opts := newOptions(0)
+
+		return organizationStats(cfg, opts, repositoryFilter{
+			include: includeRepos,
+			exclude: excludeRepos,
+			topics:  topics,
+		}, orgConcurrency)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(orgCmd)
+	orgCmd.Flags().StringSliceVar(&includeRepos, "include", []string{}, "Only scan repositories whose name matches one of the glob patterns. e.g. \"service-*\"")
+	orgCmd.Flags().StringSliceVar(&excludeRepos, "exclude", []string{}, "Skip repositories whose name matches one of the glob patterns")
+	orgCmd.Flags().StringSliceVar(&topics, "topic", []string{}, "Only scan repositories that have at least one of the topics")
+	orgCmd.Flags().IntVar(&orgConcurrency, "concurrency", defaultOrgWorkers, "Number of repositories scanned concurrently")
+}
+
+// repositoryFilter selects the repositories of an organization to scan
+type repositoryFilter struct {
+	include []string
+	exclude []string
+	topics  []string
+}
+
+// match reports whether the repository should be scanned. Archived repositories are never scanned.
+func (f repositoryFilter) match(r *go_github.Repository) bool {
+	if r.GetArchived() {
+		return false
+	}
+	name := r.GetName()
+	if len(f.include) > 0 && !matchAny(f.include, name) {
+		return false
+	}
+	if matchAny(f.exclude, name) {
+		return false
+	}
+	if len(f.topics) > 0 && !slices.ContainsFunc(f.topics, func(t string) bool {
+		return slices.Contains(r.Topics, t)
+	}) {
+		return false
+	}
+	return true
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func organizationStats(cfg config, opt options, filter repositoryFilter, workers int) error {
+	sess, err := newSession(cfg, opt, "organization", repositoriesText,
+		"concurrency", workers,
+	)
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+
+	// The rate limit budget is shared by every repository.
+	// Once it is exhausted, repositories that have not been started yet are reported as rate limited instead of being fetched.
+	var rateLimited atomic.Bool
+
+	all, err := sess.client.FetchRepositories(sess.ctx, cfg.org)
+	if err != nil {
+		if !isRateLimitError(err) {
+			return err
+		}
+		// The repositories listed so far are reported as rate limited instead of aborting the scan
+		sess.log.Warn("rate limit reached while listing repositories", "listed", len(all))
+		rateLimited.Store(true)
+	}
+	repos := make([]*go_github.Repository, 0, len(all))
+	for _, r := range all {
+		if filter.match(r) {
+			repos = append(repos, r)
+		}
+	}
+	sess.log.Info("selected repositories", "total", len(all), "selected", len(repos))
+
+	var done atomic.Int32
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := concurrency.NewSemaphore(workers)
+	results := make([]*parser.RepositoryStatsSummary, len(repos))
+
+	for i, r := range repos {
+		if err := sem.Acquire(sess.ctx); err != nil {
+			return err
+		}
+		wg.Add(1)
+		go func(i int, r *go_github.Repository) {
+			defer func() {
+				sem.Release()
+				wg.Done()
+				n := done.Add(1)
+				mu.Lock()
+				sess.progress(fmt.Sprintf("  fetching repositories... (%d/%d)", n, len(repos)), "green")
+				mu.Unlock()
+			}()
+
+			if rateLimited.Load() {
+				results[i] = &parser.RepositoryStatsSummary{Repository: r.GetName(), RateLimited: true}
+				return
+			}
+
+			rcfg := cfg
+			rcfg.repo = r.GetName()
+			rss, err := fetchRepositoryStats(sess.ctx, sess.client, rcfg, opt, sess.log.With("repo", r.GetName()), nil, true)
+			if err != nil {
+				// A failing repository is reported and does not abort the scan
+				sess.log.Warn("failed to fetch repository stats", "repo", r.GetName(), "error", err)
+				rss = &parser.RepositoryStatsSummary{Error: err.Error()}
+			}
+			if rss.RateLimited {
+				rateLimited.Store(true)
+			}
+			rss.Repository = r.GetName()
+			results[i] = rss
+		}(i, r)
+	}
+	wg.Wait()
+
+	sess.spinner.Stop()
+
+	oss := parser.OrganizationParse(cfg.org, results)
+
+	return printStats(opt, oss, rateLimited.Load(), func(w io.Writer) {
+		printer.Organization(w, oss)
+	})
+}
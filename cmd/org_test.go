@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+
+	go_github "github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryFilterMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter repositoryFilter
+		repo   *go_github.Repository
+		want   bool
+	}{
+		{
+			name:   "No filters",
+			filter: repositoryFilter{},
+			repo:   &go_github.Repository{Name: go_github.String("web")},
+			want:   true,
+		},
+		{
+			name:   "Archived repositories are skipped",
+			filter: repositoryFilter{},
+			repo:   &go_github.Repository{Name: go_github.String("web"), Archived: go_github.Bool(true)},
+			want:   false,
+		},
+		{
+			name:   "Include pattern matches",
+			filter: repositoryFilter{include: []string{"api-*", "service-*"}},
+			repo:   &go_github.Repository{Name: go_github.String("service-users")},
+			want:   true,
+		},
+		{
+			name:   "Include pattern does not match",
+			filter: repositoryFilter{include: []string{"service-*"}},
+			repo:   &go_github.Repository{Name: go_github.String("web")},
+			want:   false,
+		},
+		{
+			name:   "Exclude wins over include",
+			filter: repositoryFilter{include: []string{"service-*"}, exclude: []string{"*-legacy"}},
+			repo:   &go_github.Repository{Name: go_github.String("service-legacy")},
+			want:   false,
+		},
+		{
+			name:   "Topic matches",
+			filter: repositoryFilter{topics: []string{"backend", "go"}},
+			repo:   &go_github.Repository{Name: go_github.String("api"), Topics: []string{"go"}},
+			want:   true,
+		},
+		{
+			name:   "Topic does not match",
+			filter: repositoryFilter{topics: []string{"backend"}},
+			repo:   &go_github.Repository{Name: go_github.String("web"), Topics: []string{"frontend"}},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.match(tt.repo))
+		})
+	}
+}
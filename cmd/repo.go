@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/github"
+	"github.com/fchimpan/gh-workflow-stats/internal/logger"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+	"github.com/spf13/cobra"
+
+	go_github "github.com/google/go-github/v60/github"
+)
+
+const workflowsText = "  fetching workflows..."
+
+var repoCmd = &cobra.Command{
+	Use:     "repo",
+	Short:   "Fetch stats of every workflow in the repository. Compare the success rate and execution time of workflows.",
+	Example: `$ gh workflow-stats repo --org=OWNER --repo=REPO`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolveHost(cmd, &host)
+
+		if err := validateRepositoryFlags(org, repo); err != nil {
+			return err
+		}
+		if err := validateOutputFlags(documentFormats...); err != nil {
+			return err
+		}
+
+		cfg := createConfig(host, org, repo, "", -1)
+		opts := newOptions(0)
+
+		return repositoryStats(cfg, opts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(repoCmd)
+}
+
+func repositoryStats(cfg config, opt options) error {
+	sess, err := newSession(cfg, opt, "repository", workflowsText)
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+
+	rss, err := fetchRepositoryStats(sess.ctx, sess.client, cfg, opt, sess.log, func(wf *go_github.Workflow) {
+		sess.progress(fmt.Sprintf("  fetching workflow runs of %s...", wf.GetName()), "green")
+	}, false)
+	if err != nil {
+		return err
+	}
+
+	sess.spinner.Stop()
+
+	return printStats(opt, rss, rss.RateLimited, func(w io.Writer) {
+		printer.Repository(w, rss)
+	})
+}
+
+// fetchRepositoryStats fetches the runs of every workflow in the repository and summarizes them.
+// withJobs also fetches and summarizes the jobs of the runs. They are fetched too when the duration mode needs them.
+// When the rate limit is reached, the workflows fetched so far are summarized and the result is marked as rate limited.
+func fetchRepositoryStats(ctx context.Context, client *github.WorkflowStatsClient, cfg config, opt options, log logger.Logger, progress func(*go_github.Workflow), withJobs bool) (*parser.RepositoryStatsSummary, error) {
+	workflows, err := client.FetchWorkflows(ctx, cfg.org, cfg.repo)
+	if err != nil {
+		if !isRateLimitError(err) {
+			return nil, err
+		}
+		rss := parser.RepositoryParse(workflows, nil, nil, parseOptions(opt, nil))
+		rss.RateLimited = true
+		return rss, nil
+	}
+
+	rateLimited := false
+	runs := make(map[int64][]*go_github.WorkflowRun, len(workflows))
+	var jobs map[int64][]*go_github.WorkflowJob
+	if withJobs || needsJobs(opt) {
+		jobs = make(map[int64][]*go_github.WorkflowJob, len(workflows))
+	}
+	for _, wf := range workflows {
+		if progress != nil {
+			progress(wf)
+		}
+
+		wcfg := workflowConfig(cfg, wf)
+		store := openCache(wcfg, opt, log)
+		r, err := fetchWorkflowRuns(ctx, client, store, wcfg, opt)
+		runs[wf.GetID()] = r
+		if err == nil && jobs != nil {
+			jobs[wf.GetID()], err = fetchWorkflowJobs(ctx, client, store, wcfg, r)
+		}
+		saveCache(store, log)
+		if err != nil {
+			if !isRateLimitError(err) {
+				return nil, err
+			}
+			// The rate limit is shared by all workflows, so the remaining workflows cannot be fetched either
+			log.Warn("rate limit reached, skipping remaining workflows", "workflow", wf.GetName())
+			rateLimited = true
+			break
+		}
+	}
+
+	rss := parser.RepositoryParse(workflows, runs, jobs, parseOptions(opt, nil))
+	rss.RateLimited = rateLimited
+	return rss, nil
+}
+
+// workflowConfig returns the config of a workflow of the repository.
+// Workflows in .github/workflows are identified by their file name, as with -f, so that they share the cache of the workflow commands.
+// Other workflows, e.g. the dynamic workflows of the default CodeQL setup, have no file and are identified by ID.
+func workflowConfig(cfg config, wf *go_github.Workflow) config {
+	cfg.workflowFileName = ""
+	cfg.workflowID = wf.GetID()
+	if path.Dir(wf.GetPath()) == ".github/workflows" {
+		cfg.workflowFileName = path.Base(wf.GetPath())
+	}
+	return cfg
+}
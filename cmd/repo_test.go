@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	go_github "github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowConfig(t *testing.T) {
+	cfg := config{org: "owner", repo: "repo", workflowID: -1}
+
+	tests := []struct {
+		name     string
+		path     string
+		wantFile string
+		wantKey  string
+	}{
+		{name: "Workflow file", path: ".github/workflows/ci.yaml", wantFile: "ci.yaml", wantKey: "ci.yaml"},
+		{name: "Dynamic workflow", path: "dynamic/github-code-scanning/codeql", wantFile: "", wantKey: "42"},
+		{name: "No path", path: "", wantFile: "", wantKey: "42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := workflowConfig(cfg, &go_github.Workflow{ID: go_github.Int64(42), Path: go_github.String(tt.path)})
+			assert.Equal(t, tt.wantFile, got.workflowFileName)
+			assert.Equal(t, int64(42), got.workflowID)
+			assert.Equal(t, tt.wantKey, workflowKey(got))
+			assert.Equal(t, "repo", got.repo)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+	"github.com/fchimpan/gh-workflow-stats/internal/types"
+	"github.com/spf13/cobra"
+
+	go_github "github.com/google/go-github/v60/github"
+)
+
+var numRerunActors int
+
+var retriesCmd = &cobra.Command{
+	Use:     "retries",
+	Short:   "Report re-run overhead: runs that needed a re-run, attempts per run, time lost to failed attempts and who triggers re-runs.",
+	Example: `$ gh workflow-stats retries --org=OWNER --repo=REPO -f ci.yaml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolveHost(cmd, &host)
+
+		if err := validateFlags(org, repo, fileName, id); err != nil {
+			return err
+		}
+		if err := validateOutputFlags(documentFormats...); err != nil {
+			return err
+		}
+		if err := validateStatsFlags(); err != nil {
+			return err
+		}
+
+		if numRerunActors < 1 {
+			numRerunActors = 1
+		}
+
+		cfg := createConfig(host, org, repo, fileName, id)
+		opts := newOptions(numRerunActors)
+
+		return retriesStats(cfg, opts)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(retriesCmd)
+	retriesCmd.Flags().IntVarP(&numRerunActors, "num-actors", "n", types.DefaultJobCount, "Number of actors to display")
+	addDurationModeFlag(retriesCmd)
+}
+
+func retriesStats(cfg config, opt options) error {
+	sess, err := newSession(cfg, opt, "retries", workflowRunsText)
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+	sess.openCache(cfg, opt)
+
+	runs, err := sess.fetchRuns(cfg, opt)
+	if err != nil {
+		return err
+	}
+	var jobs []*go_github.WorkflowJob
+	if needsJobs(opt) {
+		jobs, err = sess.fetchJobs(cfg, runs)
+		if err != nil {
+			return err
+		}
+	}
+
+	sess.spinner.Stop()
+
+	rs := parser.WorkflowRetriesParse(runs, parseOptions(opt, jobs))
+
+	return printStats(opt, rs, sess.isRateLimit, func(w io.Writer) {
+		printer.Retries(w, rs, opt.jobNum)
+	})
+}
@@ -3,6 +3,8 @@ package cmd
 import (
 	"os"
 
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/fchimpan/gh-workflow-stats/internal/types"
 	"github.com/spf13/cobra"
 )
 
@@ -24,6 +26,15 @@ var (
 	checkSuiteID        int64
 	debug               bool
 	verbose             bool
+	noCache             bool
+	refresh             bool
+	format              string
+	table               string
+	percentiles         []float64
+	durationMode        string
+	attempts            string
+	histogram           bool
+	buckets             int
 )
 
 var rootCmd = &cobra.Command{
@@ -36,10 +47,15 @@ var rootCmd = &cobra.Command{
 		if err := validateFlags(org, repo, fileName, id); err != nil {
 			return err
 		}
+		if err := validateOutputFlags(statsFormats...); err != nil {
+			return err
+		}
+		if err := validateStatsFlags(); err != nil {
+			return err
+		}
 
 		cfg := createConfig(host, org, repo, fileName, id)
-		opts := createOptions(actor, branch, event, status, created, headSHA,
-			excludePullRequests, all, js, checkSuiteID, 0)
+		opts := newOptions(0)
 
 		return workflowStats(cfg, opts, false)
 	},
@@ -60,6 +76,12 @@ func init() {
 	rootCmd.PersistentFlags().Int64VarP(&id, "id", "i", -1, "The ID of the workflow. You can also pass the workflow file name as a string.")
 	rootCmd.PersistentFlags().BoolVarP(&all, "all", "A", false, "Target all workflows in the repository. If specified, default fetches of 100 workflow runs is overridden to all workflow runs. Note the GitHub API rate limit.")
 	rootCmd.PersistentFlags().BoolVar(&js, "json", false, "Output as JSON")
+	rootCmd.PersistentFlags().StringVar(&format, "format", string(types.OutputFormatText), "Output format. One of text, json, markdown, html, openmetrics, csv or tsv. Formats other than text and json are only supported by the workflow and jobs stats.")
+	rootCmd.PersistentFlags().Float64SliceVar(&percentiles, "percentiles", nil, "Percentiles of the execution durations to report, between 0 and 100. e.g. 50,90,95,99")
+	addDurationModeFlag(rootCmd)
+	addAttemptsFlag(rootCmd)
+	addHistogramFlags(rootCmd)
+	rootCmd.PersistentFlags().StringVar(&table, "table", string(types.OutputTableRuns), "Table emitted by the csv and tsv formats. One of runs, jobs or steps.")
 
 	// Workflow runs query parameters
 	// See https://docs.github.com/en/rest/actions/workflow-runs?apiVersion=2022-11-28#list-workflow-runs-for-a-workflow
@@ -72,7 +94,27 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&excludePullRequests, "exclude-pull-requests", "x", false, "Workflow run exclude pull requests")
 	rootCmd.PersistentFlags().Int64VarP(&checkSuiteID, "check-suite-id", "C", 0, "Workflow run check suite ID")
 
+	// Cache flags
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Do not read or write the local cache of completed workflow runs and jobs")
+	rootCmd.PersistentFlags().BoolVar(&refresh, "refresh", false, "Discard the local cache of the workflow and fetch all workflow runs and jobs again")
+
 	// Debug and logging flags
 	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "d", false, "Enable debug mode with detailed logging")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging (info level)")
 }
+
+// addDurationModeFlag adds --duration-mode to the commands that measure the duration of runs through the parse options
+func addDurationModeFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&durationMode, "duration-mode", parser.DurationModeUpdated, "How the duration of a workflow run is measured. One of updated (from the run start to its last update) or jobs (from the first job start to the last job completion, also reports the sum of job time). jobs fetches the jobs of every run.")
+}
+
+// addAttemptsFlag adds --attempts to the commands that filter the attempts of the runs through the parse options
+func addAttemptsFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&attempts, "attempts", "", "Attempts of the runs that feed the stats. One of first, last or all. Also reports the first-attempt, final and per-attempt success rates. By default every attempt feeds the stats.")
+}
+
+// addHistogramFlags adds --histogram and --buckets to the commands that print duration histograms
+func addHistogramFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&histogram, "histogram", false, "Print the distribution of the durations of the runs and jobs as a histogram and flag bimodal distributions, e.g. cache hit and cache miss")
+	cmd.Flags().IntVar(&buckets, "buckets", parser.DefaultHistogramBuckets, "Number of buckets of the --histogram")
+}
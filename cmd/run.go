@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/errors"
+	"github.com/fchimpan/gh-workflow-stats/internal/github"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+	"github.com/spf13/cobra"
+
+	go_github "github.com/google/go-github/v60/github"
+)
+
+var (
+	runAttempt int
+	svgFile    string
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run RUN_ID",
+	Short: "Render the timeline of a workflow run: when every job was queued, ran and switched steps.",
+	Example: `$ gh workflow-stats run 1234567890 --org=OWNER --repo=REPO
+$ gh workflow-stats run 1234567890 --org=OWNER --repo=REPO --attempt=2 --svg=timeline.svg`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolveHost(cmd, &host)
+
+		if err := validateRepositoryFlags(org, repo); err != nil {
+			return err
+		}
+		if err := validateOutputFlags(documentFormats...); err != nil {
+			return err
+		}
+		runID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil || runID <= 0 {
+			return errors.NewConfigurationError("run ID must be a positive integer", err).
+				WithContext("run_id", args[0])
+		}
+		if runAttempt < 0 {
+			return errors.NewConfigurationError("--attempt must not be negative", nil).
+				WithContext("attempt", strconv.Itoa(runAttempt))
+		}
+
+		cfg := createConfig(host, org, repo, fileName, id)
+		opts := newOptions(0)
+
+		return runTimeline(cfg, opts, runID, runAttempt, svgFile)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	runCmd.Flags().IntVar(&runAttempt, "attempt", 0, "Attempt of the run. Default is the latest attempt")
+	runCmd.Flags().StringVar(&svgFile, "svg", "", "Also write the timeline as an SVG image to the given file")
+}
+
+func runTimeline(cfg config, opt options, runID int64, attempt int, svgPath string) error {
+	sess, err := newSession(cfg, opt, "run timeline", workflowJobsText,
+		"run_id", runID,
+		"run_attempt", attempt,
+	)
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+
+	runsCfg := &github.WorkflowRunsConfig{
+		Org:  cfg.org,
+		Repo: cfg.repo,
+	}
+	run, err := sess.client.FetchWorkflowRunAttempt(sess.ctx, runsCfg, runID, attempt)
+	if err != nil {
+		return err
+	}
+	jobs, err := sess.client.FetchWorkflowJobsAttempts(sess.ctx, []*go_github.WorkflowRun{run}, runsCfg)
+	if err := sess.check(err); err != nil {
+		return err
+	}
+
+	sess.spinner.Stop()
+
+	tl := parser.WorkflowRunTimelineParse(run, jobs, time.Now())
+
+	if svgPath != "" {
+		if err := writeTimelineSVG(svgPath, tl); err != nil {
+			return err
+		}
+	}
+
+	return printStats(opt, tl, sess.isRateLimit, func(w io.Writer) {
+		printer.Timeline(w, tl)
+	})
+}
+
+// writeTimelineSVG writes the timeline of a run as an SVG image to path
+func writeTimelineSVG(path string, tl *parser.RunTimeline) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.NewConfigurationError("failed to create the SVG file", err).
+			WithContext("path", path)
+	}
+	if err := printer.TimelineSVG(f, tl); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTimelineSVG(t *testing.T) {
+	dir := t.TempDir()
+	tl := &parser.RunTimeline{RunID: 1, RunAttempt: 1, Name: "CI", Duration: 60, Jobs: []*parser.JobTimeline{
+		{Name: "build", Conclusion: parser.ConclusionSuccess, CompletedOffset: 60, ExecutionDuration: 60},
+	}}
+
+	path := filepath.Join(dir, "timeline.svg")
+	require.NoError(t, writeTimelineSVG(path, tl))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "<svg")
+	assert.Contains(t, string(b), "build")
+
+	assert.Error(t, writeTimelineSVG(filepath.Join(dir, "missing", "timeline.svg"), tl))
+}
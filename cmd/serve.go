@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/errors"
+	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultListenAddr     = ":9464"
+	defaultScrapeInterval = 5 * time.Minute
+	minScrapeInterval     = time.Minute
+)
+
+var (
+	listenAddr     string
+	scrapeInterval time.Duration
+	serveJobs      bool
+)
+
+var serveCmd = &cobra.Command{
+	Use:     "serve",
+	Short:   "Serve workflow stats as OpenMetrics on /metrics. Workflow runs are fetched again periodically.",
+	Example: `$ gh workflow-stats serve --org=OWNER --repo=REPO -f ci.yaml --listen=:9464 --interval=10m --jobs`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolveHost(cmd, &host)
+
+		if err := validateFlags(org, repo, fileName, id); err != nil {
+			return err
+		}
+		if err := validateStatsFlags(); err != nil {
+			return err
+		}
+		if scrapeInterval < minScrapeInterval {
+			return errors.NewConfigurationError("--interval must be at least "+minScrapeInterval.String(), nil).
+				WithContext("interval", scrapeInterval.String())
+		}
+
+		cfg := createConfig(host, org, repo, fileName, id)
+		opts := newOptions(0)
+
+		return serveMetrics(cfg, opts, listenAddr, scrapeInterval, serveJobs)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&listenAddr, "listen", defaultListenAddr, "Address to listen on")
+	serveCmd.Flags().DurationVar(&scrapeInterval, "interval", defaultScrapeInterval, "Interval between two fetches of the workflow runs. Note the GitHub API rate limit.")
+	serveCmd.Flags().BoolVar(&serveJobs, "jobs", false, "Also expose the job and step metrics. Jobs are fetched for every workflow run.")
+	addDurationModeFlag(serveCmd)
+	addAttemptsFlag(serveCmd)
+}
+
+// metricsHandler serves the last collected metrics
+type metricsHandler struct {
+	body atomic.Pointer[[]byte]
+}
+
+func (h *metricsHandler) set(b []byte) {
+	h.body.Store(&b)
+}
+
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b := h.body.Load()
+	if b == nil {
+		http.Error(w, "metrics are not collected yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", printer.OpenMetricsContentType)
+	_, _ = w.Write(*b)
+}
+
+func serveMetrics(cfg config, opt options, addr string, interval time.Duration, withJobs bool) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log := newLogger(opt)
+	log.Info("starting metrics server",
+		"org", cfg.org,
+		"repo", cfg.repo,
+		"host", cfg.host,
+		"workflow_file", cfg.workflowFileName,
+		"workflow_id", cfg.workflowID,
+		"listen", addr,
+		"interval", interval.String(),
+		"jobs", withJobs,
+	)
+
+	client, err := newClient(cfg, log)
+	if err != nil {
+		return err
+	}
+
+	h := &metricsHandler{}
+	collect := func() {
+		m, err := collectWorkflowMetrics(ctx, client, cfg, opt, withJobs, log)
+		if err != nil {
+			// Keep serving the previous metrics
+			log.Warn("failed to collect metrics", "error", err)
+			return
+		}
+		var buf bytes.Buffer
+		if err := printer.OpenMetrics(&buf, []*printer.WorkflowMetrics{m}); err != nil {
+			log.Warn("failed to render metrics", "error", err)
+			return
+		}
+		h.set(buf.Bytes())
+	}
+
+	go func() {
+		collect()
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				collect()
+			}
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", h)
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/cache"
+	"github.com/fchimpan/gh-workflow-stats/internal/github"
+	"github.com/fchimpan/gh-workflow-stats/internal/logger"
+	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+
+	go_github "github.com/google/go-github/v60/github"
+)
+
+// session is the setup shared by the commands: the logger, the GitHub client, the cache of the workflow and the spinner shown while fetching.
+// Rate limit errors are recorded in isRateLimit so that the results fetched so far are reported instead of failing.
+type session struct {
+	ctx         context.Context
+	log         logger.Logger
+	client      *github.WorkflowStatsClient
+	store       *cache.Store
+	spinner     *printer.Spinner
+	isRateLimit bool
+}
+
+// newSession logs the start of the stats, creates the client and starts the spinner with text.
+// logArgs are added to the start log. The session must be closed.
+func newSession(cfg config, opt options, stats, text string, logArgs ...any) (*session, error) {
+	log := newLogger(opt)
+
+	log.Info("starting "+stats+" stats", append([]any{
+		"org", cfg.org,
+		"repo", cfg.repo,
+		"host", cfg.host,
+		"workflow_file", cfg.workflowFileName,
+		"workflow_id", cfg.workflowID,
+		"output_json", opt.js,
+	}, logArgs...)...)
+
+	client, err := newClient(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := printer.NewSpinner(printer.SpinnerOptions{
+		Text:          text,
+		CharSetsIndex: charSize,
+		Color:         "green",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sess := &session{
+		ctx:     context.Background(),
+		log:     log,
+		client:  client,
+		spinner: s,
+	}
+	s.Start()
+	return sess, nil
+}
+
+// openCache opens the cache of the workflow of cfg. The runs and jobs of the session are then served from it.
+func (s *session) openCache(cfg config, opt options) {
+	s.store = openCache(cfg, opt, s.log)
+}
+
+// close stops the spinner and saves the cache
+func (s *session) close() {
+	s.spinner.Stop()
+	saveCache(s.store, s.log)
+}
+
+// progress updates the text of the spinner
+func (s *session) progress(text, color string) {
+	s.spinner.Update(printer.SpinnerOptions{
+		Text:          text,
+		CharSetsIndex: charSize,
+		Color:         color,
+	})
+}
+
+// check records a rate limit error and drops it, so that the partial results fetched so far are reported. Other errors are returned.
+func (s *session) check(err error) error {
+	if err != nil && isRateLimitError(err) {
+		s.isRateLimit = true
+		return nil
+	}
+	return err
+}
+
+// fetchRuns fetches the workflow runs of cfg
+func (s *session) fetchRuns(cfg config, opt options) ([]*go_github.WorkflowRun, error) {
+	runs, err := fetchWorkflowRuns(s.ctx, s.client, s.store, cfg, opt)
+	return runs, s.check(err)
+}
+
+// fetchJobs fetches the jobs of every attempt of the runs
+func (s *session) fetchJobs(cfg config, runs []*go_github.WorkflowRun) ([]*go_github.WorkflowJob, error) {
+	s.progress(workflowJobsText, "pink")
+	jobs, err := fetchWorkflowJobs(s.ctx, s.client, s.store, cfg, runs)
+	return jobs, s.check(err)
+}
+
+// printStats prints v as JSON with --json. Otherwise it prints the rate limit warning, if reached, and the text report of print.
+func printStats(opt options, v any, isRateLimit bool, print func(w io.Writer)) error {
+	if opt.js {
+		bytes, err := json.MarshalIndent(v, "", "	")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bytes))
+		return nil
+	}
+
+	if isRateLimit {
+		printer.RateLimitWarning(os.Stdout)
+	}
+	print(os.Stdout)
+	return nil
+}
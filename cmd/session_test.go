@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionCheck(t *testing.T) {
+	s := &session{}
+
+	assert.NoError(t, s.check(nil))
+	assert.False(t, s.isRateLimit)
+
+	err := errors.New("not found")
+	assert.Equal(t, err, s.check(err))
+	assert.False(t, s.isRateLimit)
+
+	assert.NoError(t, s.check(&github.RateLimitError{Err: errors.New("rate limited")}))
+	assert.True(t, s.isRateLimit)
+}
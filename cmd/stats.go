@@ -9,19 +9,24 @@ import (
 	"log/slog"
 	"os"
 	"slices"
+	"time"
 
+	"github.com/fchimpan/gh-workflow-stats/internal/cache"
 	"github.com/fchimpan/gh-workflow-stats/internal/github"
 	"github.com/fchimpan/gh-workflow-stats/internal/logger"
 	"github.com/fchimpan/gh-workflow-stats/internal/parser"
 	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+	"github.com/fchimpan/gh-workflow-stats/internal/types"
 
 	go_github "github.com/google/go-github/v60/github"
 )
 
 const (
-	workflowRunsText = "  fetching workflow runs..."
-	workflowJobsText = "  fetching workflow jobs..."
-	charSize         = 14
+	workflowRunsText   = "  fetching workflow runs..."
+	workflowJobsText   = "  fetching workflow jobs..."
+	jobLogsText        = "  fetching job logs..."
+	jobAnnotationsText = "  fetching job annotations..."
+	charSize           = 14
 )
 
 type config struct {
@@ -44,108 +49,123 @@ type options struct {
 	all                 bool
 	js                  bool
 	jobNum              int
+	noCache             bool
+	refresh             bool
+	format              types.OutputFormat
+	table               types.OutputTable
+	percentiles         []float64
+	durationMode        string
+	attempts            string
+	histogramBuckets    int
+	queue               bool
+	failureLogs         bool
+	groupBy             []string
+	matrix              bool
+	matrixAxis          string
+	timeZone            *time.Location
 }
 
 func workflowStats(cfg config, opt options, isJobs bool) error {
-	ctx := context.Background()
 	w := io.Writer(os.Stdout)
 
-	// Initialize logger based on output format and debug flags
-	var log logger.Logger
-	if opt.js {
-		// Use no-op logger for JSON output to avoid interfering with JSON
-		log = logger.NewNoOpLogger()
-	} else {
-		// Determine log level based on flags
-		logLevel := determineLogLevel()
-		if logLevel == slog.Level(100) { // Custom level for no logging
-			// Use no-op logger when logging is disabled
-			log = logger.NewNoOpLogger()
-		} else {
-			// Use stderr for logging so it doesn't interfere with normal output
-			log = logger.NewLogger(logLevel, os.Stderr)
-		}
-	}
-
-	log.Info("starting workflow stats",
-		"org", cfg.org,
-		"repo", cfg.repo,
-		"host", cfg.host,
-		"workflow_file", cfg.workflowFileName,
-		"workflow_id", cfg.workflowID,
+	sess, err := newSession(cfg, opt, "workflow", workflowRunsText,
 		"is_jobs", isJobs,
-		"output_json", opt.js,
 	)
-
-	a := &github.GitHubAuthenticator{}
-	client, err := github.NewClient(cfg.host, a, log)
 	if err != nil {
-		github.LogError(log, err, "client_creation", map[string]interface{}{
-			"host": cfg.host,
-		})
 		return err
 	}
+	defer sess.close()
+	sess.openCache(cfg, opt)
 
-	s, err := printer.NewSpinner(printer.SpinnerOptions{
-		Text:          workflowRunsText,
-		CharSetsIndex: charSize,
-		Color:         "green",
-	})
+	runs, err := sess.fetchRuns(cfg, opt)
 	if err != nil {
 		return err
 	}
-	s.Start()
-	defer s.Stop()
 
-	isRateLimit := false
-	runs, err := fetchWorkflowRuns(ctx, client, cfg, opt)
-	if err != nil {
-		if errors.As(err, &github.RateLimitError{}) {
-			isRateLimit = true
-		} else {
-			return err
-		}
-	}
+	// The jobs and steps tables and the job timings need the jobs even without the jobs command
+	fetchJobs := isJobs || needsJobs(opt) || (opt.format.IsTabular() && opt.table != types.OutputTableRuns)
 
+	var rawJobs []*go_github.WorkflowJob
 	var jobs []*parser.WorkflowJobsStatsSummary
-	if isJobs {
-		s.Update(printer.SpinnerOptions{
-			Text:          workflowJobsText,
-			CharSetsIndex: charSize,
-			Color:         "pink",
-		})
-		j, err := client.FetchWorkflowJobsAttempts(ctx, runs, &github.WorkflowRunsConfig{
-			Org:  cfg.org,
-			Repo: cfg.repo,
-		})
+	var queue *parser.QueueSummary
+	var failureLogs *parser.FailureLogsSummary
+	if fetchJobs {
+		rawJobs, err = sess.fetchJobs(cfg, runs)
 		if err != nil {
-			if errors.As(err, &github.RateLimitError{}) {
-				isRateLimit = true
-			} else {
+			return err
+		}
+		if isJobs {
+			jobs = parser.WorkflowJobsParseWithOptions(rawJobs, parseOptions(opt, nil))
+		}
+		if isJobs && opt.queue {
+			queue = parser.WorkflowJobsQueueParse(rawJobs, opt.timeZone, parseOptions(opt, nil))
+		}
+		if isJobs && opt.failureLogs {
+			sess.progress(jobLogsText, "red")
+			logs, err := fetchFailedJobLogs(sess.ctx, sess.client, cfg, rawJobs)
+			if err := sess.check(err); err != nil {
 				return err
 			}
+			failureLogs = parser.WorkflowFailureLogsParse(rawJobs, logs)
 		}
-		jobs = parser.WorkflowJobsParse(j)
 	}
 
-	s.Stop()
+	sess.spinner.Stop()
+	isRateLimit := sess.isRateLimit
 
-	wrs := parser.WorkflowRunsParse(runs)
+	wrs := parser.WorkflowRunsParseWithOptions(runs, parseOptions(opt, rawJobs))
 
-	if opt.js {
+	switch {
+	case opt.js:
 		res := &parser.Result{
 			WorkflowRunsStatsSummary: wrs,
 			WorkflowJobsStatsSummary: []*parser.WorkflowJobsStatsSummary{},
 		}
 		if isJobs {
 			res.WorkflowJobsStatsSummary = jobs
+			res.WorkflowJobsQueueSummary = queue
+			res.WorkflowJobsFailureLogs = failureLogs
 		}
 		bytes, err := json.MarshalIndent(res, "", "	")
 		if err != nil {
 			return err
 		}
 		fmt.Println(string(bytes))
-	} else {
+	case opt.format.IsTabular():
+		if isRateLimit {
+			// Keep stdout a valid table
+			printer.RateLimitWarning(os.Stderr)
+		}
+		return writeTable(w, opt, wrs, rawJobs)
+	case opt.format == types.OutputFormatHTML:
+		ts, err := parser.WorkflowRunsTrendParse(runs, parser.PeriodDay, time.UTC)
+		if err != nil {
+			return err
+		}
+		r := printer.HTMLReport{
+			Title:       fmt.Sprintf("%s/%s %s", cfg.org, cfg.repo, workflowKey(cfg)),
+			GeneratedAt: time.Now(),
+			RateLimited: isRateLimit,
+			Runs:        wrs,
+			Trend:       ts,
+			Histogram:   parser.Histogram(parser.SuccessDurations(wrs), parser.DefaultHistogramBuckets),
+		}
+		if isJobs {
+			r.Jobs = jobs
+		}
+		return printer.HTML(w, r)
+	case opt.format == types.OutputFormatOpenMetrics:
+		return printer.OpenMetrics(w, []*printer.WorkflowMetrics{newWorkflowMetrics(cfg, wrs, jobs, isRateLimit)})
+	case opt.format == types.OutputFormatMarkdown:
+		if isRateLimit {
+			printer.RateLimitWarningMarkdown(w)
+		}
+		printer.RunsMarkdown(w, wrs)
+		if isJobs {
+			printer.FailureJobsMarkdown(w, jobs, opt.jobNum)
+			printer.LongestDurationJobsMarkdown(w, jobs, opt.jobNum)
+		}
+	default:
 		if isRateLimit {
 			printer.RateLimitWarning(os.Stdout)
 		}
@@ -154,12 +174,57 @@ func workflowStats(cfg config, opt options, isJobs bool) error {
 			printer.FailureJobs(w, jobs, opt.jobNum)
 			printer.LongestDurationJobs(w, jobs, opt.jobNum)
 		}
+		if isJobs && opt.histogramBuckets > 0 {
+			printer.JobDurationHistograms(w, jobs, opt.jobNum)
+		}
+		if isJobs && (opt.matrix || opt.matrixAxis != "") {
+			printer.MatrixLegs(w, jobs, opt.jobNum)
+		}
+		if queue != nil {
+			printer.Queue(w, queue)
+		}
+		if failureLogs != nil {
+			printer.FailureLogs(w, failureLogs, opt.jobNum)
+		}
 	}
 
 	return nil
 }
 
-func fetchWorkflowRuns(ctx context.Context, client *github.WorkflowStatsClient, cfg config, opt options) ([]*go_github.WorkflowRun, error) {
+// writeTable writes the table selected by --table as CSV or TSV
+func writeTable(w io.Writer, opt options, wrs *parser.WorkflowRunsStatsSummary, jobs []*go_github.WorkflowJob) error {
+	comma := ','
+	if opt.format == types.OutputFormatTSV {
+		comma = '\t'
+	}
+	switch opt.table {
+	case types.OutputTableJobs:
+		return printer.JobsTable(w, parser.WorkflowJobRows(jobs), comma)
+	case types.OutputTableSteps:
+		return printer.StepsTable(w, parser.WorkflowStepRows(jobs), comma)
+	default:
+		return printer.RunsTable(w, parser.WorkflowRunRows(wrs), comma)
+	}
+}
+
+func fetchWorkflowRuns(ctx context.Context, client *github.WorkflowStatsClient, store *cache.Store, cfg config, opt options) ([]*go_github.WorkflowRun, error) {
+	created := opt.created
+	query := queryKey(opt)
+
+	// With a cache watermark only runs created since the watermark, or within the re-run window, are requested.
+	// Runs created before are completed and are served from the cache.
+	// Without --all a single page is still enough: if it is full, no cached run is among the latest runs anyway.
+	var cached []*go_github.WorkflowRun
+	var wm cache.Watermark
+	hasWatermark := false
+	if store != nil && opt.created == "" {
+		wm, hasWatermark = store.Watermark(query)
+		if hasWatermark && (wm.All || !opt.all) {
+			created = createdSince(wm, time.Now())
+			cached = filterCachedRuns(store.Runs(), opt)
+		}
+	}
+
 	// Intentionally not using Github API status filter as it applies only to the last run attempt.
 	// Instead retrieving all qualifying workflow runs and their run attempts and filtering by status manually (if needed)
 	runs, err := client.FetchWorkflowRuns(ctx, &github.WorkflowRunsConfig{
@@ -173,12 +238,29 @@ func fetchWorkflowRuns(ctx context.Context, client *github.WorkflowStatsClient,
 		Branch:              opt.branch,
 		Event:               opt.event,
 		Status:              "",
-		Created:             opt.created,
+		Created:             created,
 		HeadSHA:             opt.headSHA,
 		ExcludePullRequests: opt.excludePullRequests,
 		CheckSuiteID:        opt.checkSuiteID,
 	},
 	)
+
+	if store != nil {
+		store.PutRuns(runs)
+	}
+	runs = mergeRuns(cached, runs)
+	if store != nil && err == nil && opt.created == "" && advancesWatermark(wm, hasWatermark, opt.all) {
+		if c, ok := cache.NextWatermark(runs); ok {
+			store.SetWatermark(query, cache.Watermark{
+				CreatedAt: c,
+				All:       opt.all,
+			})
+		}
+	}
+	if !opt.all {
+		runs = latestRuns(runs, types.DefaultPerPage)
+	}
+
 	if err != nil {
 		// Check if we have partial results (e.g., from rate limiting)
 		if len(runs) > 0 {
@@ -191,6 +273,47 @@ func fetchWorkflowRuns(ctx context.Context, client *github.WorkflowStatsClient,
 	return filterRunAttemptsByStatus(runs, opt.status), nil
 }
 
+// fetchWorkflowJobs fetches the jobs of every run attempt. Jobs of completed runs are served from the cache when available.
+func fetchWorkflowJobs(ctx context.Context, client *github.WorkflowStatsClient, store *cache.Store, cfg config, runs []*go_github.WorkflowRun) ([]*go_github.WorkflowJob, error) {
+	runsCfg := &github.WorkflowRunsConfig{
+		Org:  cfg.org,
+		Repo: cfg.repo,
+	}
+	if store == nil {
+		return client.FetchWorkflowJobsAttempts(ctx, runs, runsCfg)
+	}
+
+	jobs := make([]*go_github.WorkflowJob, 0, len(runs))
+	missing := make([]*go_github.WorkflowRun, 0, len(runs))
+	for _, r := range runs {
+		if j, ok := store.Jobs(r); ok {
+			jobs = append(jobs, j...)
+			continue
+		}
+		missing = append(missing, r)
+	}
+
+	fetched, err := client.FetchWorkflowJobsAttempts(ctx, missing, runsCfg)
+	store.PutJobs(missing, fetched)
+	return append(jobs, fetched...), err
+}
+
+// fetchFailedJobLogs downloads the logs of the failed jobs, keyed by job ID
+func fetchFailedJobLogs(ctx context.Context, client *github.WorkflowStatsClient, cfg config, jobs []*go_github.WorkflowJob) (map[int64]string, error) {
+	return client.FetchJobLogs(ctx, &github.WorkflowRunsConfig{Org: cfg.org, Repo: cfg.repo}, failedJobs(jobs))
+}
+
+// failedJobs returns the jobs that concluded with a failure
+func failedJobs(jobs []*go_github.WorkflowJob) []*go_github.WorkflowJob {
+	failed := make([]*go_github.WorkflowJob, 0, len(jobs))
+	for _, j := range jobs {
+		if j.GetConclusion() == parser.ConclusionFailure {
+			failed = append(failed, j)
+		}
+	}
+	return failed
+}
+
 func filterRunAttemptsByStatus(runs []*go_github.WorkflowRun, status []string) []*go_github.WorkflowRun {
 	if len(status) == 0 || (len(status) == 1 && status[0] == "") {
 		return runs
@@ -204,6 +327,42 @@ func filterRunAttemptsByStatus(runs []*go_github.WorkflowRun, status []string) [
 	return filteredRuns
 }
 
+// newClient creates an authenticated GitHub client for the host
+func newClient(cfg config, log logger.Logger) (*github.WorkflowStatsClient, error) {
+	a := &github.GitHubAuthenticator{}
+	client, err := github.NewClient(cfg.host, a, log)
+	if err != nil {
+		github.LogError(log, err, "client_creation", map[string]interface{}{
+			"host": cfg.host,
+		})
+		return nil, err
+	}
+	return client, nil
+}
+
+// newLogger creates a logger based on output format and debug flags
+func newLogger(opt options) logger.Logger {
+	if opt.js {
+		// Use no-op logger for JSON output to avoid interfering with JSON
+		return logger.NewNoOpLogger()
+	}
+	// Determine log level based on flags
+	logLevel := determineLogLevel()
+	if logLevel == slog.Level(100) { // Custom level for no logging
+		// Use no-op logger when logging is disabled
+		return logger.NewNoOpLogger()
+	}
+	// Use stderr for logging so it doesn't interfere with normal output
+	return logger.NewLogger(logLevel, os.Stderr)
+}
+
+// isRateLimitError reports whether err was caused by the GitHub API rate limit
+func isRateLimitError(err error) bool {
+	var v github.RateLimitError
+	var p *github.RateLimitError
+	return errors.As(err, &v) || errors.As(err, &p)
+}
+
 func determineLogLevel() slog.Level {
 	if debug {
 		return slog.LevelDebug
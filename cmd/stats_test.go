@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/fchimpan/gh-workflow-stats/internal/github"
 	go_github "github.com/google/go-github/v60/github"
 	"github.com/stretchr/testify/assert"
 )
@@ -177,6 +180,26 @@ func TestFilterRunAttemptsByStatus(t *testing.T) {
 	}
 }
 
+func TestIsRateLimitError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Nil", err: nil, want: false},
+		{name: "Other error", err: errors.New("boom"), want: false},
+		{name: "Value", err: github.RateLimitError{}, want: true},
+		{name: "Pointer", err: &github.RateLimitError{}, want: true},
+		{name: "Wrapped pointer", err: fmt.Errorf("fetch: %w", &github.RateLimitError{}), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRateLimitError(tt.err))
+		})
+	}
+}
+
 func TestStatsConstants(t *testing.T) {
 	assert.Equal(t, "  fetching workflow runs...", workflowRunsText)
 	assert.Equal(t, "  fetching workflow jobs...", workflowJobsText)
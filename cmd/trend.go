@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/errors"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/fchimpan/gh-workflow-stats/internal/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	period   string
+	timeZone string
+)
+
+var trendCmd = &cobra.Command{
+	Use:     "trend",
+	Short:   "Fetch workflow runs stats bucketed by day, week or month. See whether the success rate and execution time improve over time.",
+	Example: `$ gh workflow-stats trend --org=OWNER --repo=REPO -f ci.yaml --period=week --timezone=Asia/Tokyo`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resolveHost(cmd, &host)
+
+		if err := validateFlags(org, repo, fileName, id); err != nil {
+			return err
+		}
+		if err := validateOutputFlags(documentFormats...); err != nil {
+			return err
+		}
+		if !parser.IsValidPeriod(period) {
+			return errors.NewConfigurationError(fmt.Sprintf("--period must be one of %s, %s or %s", parser.PeriodDay, parser.PeriodWeek, parser.PeriodMonth), nil).
+				WithContext("period", period)
+		}
+		loc, err := loadTimeZone(timeZone)
+		if err != nil {
+			return err
+		}
+
+		cfg := createConfig(host, org, repo, fileName, id)
+		opts := newOptions(0)
+
+		return trendStats(cfg, opts, period, loc)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(trendCmd)
+	trendCmd.Flags().StringVar(&period, "period", parser.PeriodWeek, "Bucket size of the trend. One of day, week or month. Weeks start on Monday.")
+	trendCmd.Flags().StringVar(&timeZone, "timezone", "UTC", "IANA time zone used to bucket workflow runs. e.g. Asia/Tokyo")
+}
+
+func trendStats(cfg config, opt options, period string, loc *time.Location) error {
+	sess, err := newSession(cfg, opt, "trend", workflowRunsText,
+		"period", period,
+		"timezone", loc.String(),
+	)
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+	sess.openCache(cfg, opt)
+
+	runs, err := sess.fetchRuns(cfg, opt)
+	if err != nil {
+		return err
+	}
+
+	sess.spinner.Stop()
+
+	ts, err := parser.WorkflowRunsTrendParse(runs, period, loc)
+	if err != nil {
+		return err
+	}
+
+	return printStats(opt, ts, sess.isRateLimit, func(w io.Writer) {
+		printer.Trend(w, ts)
+	})
+}
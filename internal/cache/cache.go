@@ -0,0 +1,262 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+const (
+	// Version of the on-disk format. Files written with a different version are discarded.
+	Version = 1
+
+	dirName       = "gh-workflow-stats"
+	statusDone    = "completed"
+	fileExtension = ".json"
+)
+
+// Key identifies the workflow a cache file belongs to
+type Key struct {
+	Host     string
+	Org      string
+	Repo     string
+	Workflow string
+}
+
+// Watermark records how far a query has been fetched.
+// Every run matching the query that was created before CreatedAt is present in the cache.
+type Watermark struct {
+	CreatedAt time.Time `json:"created_at"`
+	// All is true when the query was fetched with --all, i.e. the cache covers the full history
+	All bool `json:"all"`
+}
+
+type entry struct {
+	Version    int                              `json:"version"`
+	Runs       map[string]*github.WorkflowRun   `json:"runs"`
+	Jobs       map[string][]*github.WorkflowJob `json:"jobs"`
+	Watermarks map[string]*Watermark            `json:"watermarks"`
+}
+
+// Store is a persistent cache of completed workflow runs and their jobs.
+// Completed runs are immutable, so they are stored by run ID and attempt and never re-requested.
+type Store struct {
+	path  string
+	entry *entry
+	dirty bool
+}
+
+// DefaultDir returns the default cache directory under the user cache directory
+func DefaultDir() (string, error) {
+	d, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, dirName), nil
+}
+
+// Open loads the cache file for the given key from dir.
+// A missing or unreadable cache file results in an empty store.
+func Open(dir string, key Key) (*Store, error) {
+	if key.Host == "" || key.Org == "" || key.Repo == "" || key.Workflow == "" {
+		return nil, fmt.Errorf("cache key must have host, org, repo and workflow: %+v", key)
+	}
+	s := &Store{
+		path: filepath.Join(dir,
+			url.PathEscape(key.Host),
+			url.PathEscape(key.Org),
+			url.PathEscape(key.Repo),
+			url.PathEscape(key.Workflow)+fileExtension,
+		),
+		entry: newEntry(),
+	}
+
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return nil, err
+	}
+
+	e := newEntry()
+	if err := json.Unmarshal(b, e); err != nil || e.Version != Version {
+		// Corrupted or outdated cache files are rebuilt from scratch
+		s.dirty = true
+		return s, nil
+	}
+	if e.Runs == nil {
+		e.Runs = map[string]*github.WorkflowRun{}
+	}
+	if e.Jobs == nil {
+		e.Jobs = map[string][]*github.WorkflowJob{}
+	}
+	if e.Watermarks == nil {
+		e.Watermarks = map[string]*Watermark{}
+	}
+	s.entry = e
+	return s, nil
+}
+
+func newEntry() *entry {
+	return &entry{
+		Version:    Version,
+		Runs:       map[string]*github.WorkflowRun{},
+		Jobs:       map[string][]*github.WorkflowJob{},
+		Watermarks: map[string]*Watermark{},
+	}
+}
+
+// Path returns the location of the cache file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Reset drops every cached run, job and watermark
+func (s *Store) Reset() {
+	s.entry = newEntry()
+	s.dirty = true
+}
+
+// Runs returns all cached workflow runs
+func (s *Store) Runs() []*github.WorkflowRun {
+	runs := make([]*github.WorkflowRun, 0, len(s.entry.Runs))
+	for _, r := range s.entry.Runs {
+		runs = append(runs, r)
+	}
+	return runs
+}
+
+// PutRuns stores the completed runs. Runs that are still in progress or queued are ignored.
+func (s *Store) PutRuns(runs []*github.WorkflowRun) {
+	for _, r := range runs {
+		if r == nil || r.GetStatus() != statusDone {
+			continue
+		}
+		// Repository objects are large and identical for every run of a workflow
+		c := *r
+		c.Repository = nil
+		c.HeadRepository = nil
+		s.entry.Runs[RunKey(r.GetID(), r.GetRunAttempt())] = &c
+		s.dirty = true
+	}
+}
+
+// Jobs returns the cached jobs of a run attempt
+func (s *Store) Jobs(run *github.WorkflowRun) ([]*github.WorkflowJob, bool) {
+	jobs, ok := s.entry.Jobs[RunKey(run.GetID(), run.GetRunAttempt())]
+	return jobs, ok
+}
+
+// PutJobs stores the jobs of the given runs. Jobs of runs that are not completed are ignored.
+func (s *Store) PutJobs(runs []*github.WorkflowRun, jobs []*github.WorkflowJob) {
+	byRun := make(map[string][]*github.WorkflowJob)
+	for _, j := range jobs {
+		if j == nil {
+			continue
+		}
+		k := RunKey(j.GetRunID(), int(max(j.GetRunAttempt(), 1)))
+		byRun[k] = append(byRun[k], j)
+	}
+	for _, r := range runs {
+		if r == nil || r.GetStatus() != statusDone {
+			continue
+		}
+		k := RunKey(r.GetID(), r.GetRunAttempt())
+		if js, ok := byRun[k]; ok {
+			s.entry.Jobs[k] = js
+			s.dirty = true
+		}
+	}
+}
+
+// Watermark returns the watermark of a query
+func (s *Store) Watermark(query string) (Watermark, bool) {
+	wm, ok := s.entry.Watermarks[query]
+	if !ok || wm == nil {
+		return Watermark{}, false
+	}
+	return *wm, true
+}
+
+// SetWatermark updates the watermark of a query
+func (s *Store) SetWatermark(query string, wm Watermark) {
+	s.entry.Watermarks[query] = &wm
+	s.dirty = true
+}
+
+// Save writes the cache file if it has been modified
+func (s *Store) Save() error {
+	if !s.dirty {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	b, err := json.Marshal(s.entry)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that an interrupted run never leaves a truncated cache
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	s.dirty = false
+	return nil
+}
+
+// RunKey returns the cache key of a run attempt
+func RunKey(id int64, attempt int) string {
+	return strconv.FormatInt(id, 10) + "/" + strconv.Itoa(attempt)
+}
+
+// NextWatermark returns the watermark for the given runs.
+// It is the creation time of the oldest run that is not yet completed, so that it is fetched again next time,
+// or the creation time of the newest run when all runs are completed.
+func NextWatermark(runs []*github.WorkflowRun) (time.Time, bool) {
+	var newest, oldestPending time.Time
+	for _, r := range runs {
+		if r == nil {
+			continue
+		}
+		c := r.GetCreatedAt().Time
+		if r.GetStatus() != statusDone {
+			if oldestPending.IsZero() || c.Before(oldestPending) {
+				oldestPending = c
+			}
+			continue
+		}
+		if c.After(newest) {
+			newest = c
+		}
+	}
+	if !oldestPending.IsZero() {
+		return oldestPending.UTC(), true
+	}
+	if newest.IsZero() {
+		return time.Time{}, false
+	}
+	return newest.UTC(), true
+}
@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = Key{Host: "github.com", Org: "owner", Repo: "repo", Workflow: "ci.yaml"}
+
+func run(id int64, attempt int, status string, created time.Time) *github.WorkflowRun {
+	return &github.WorkflowRun{
+		ID:         github.Int64(id),
+		RunAttempt: github.Int(attempt),
+		Status:     github.String(status),
+		CreatedAt:  &github.Timestamp{Time: created},
+		Repository: &github.Repository{Name: github.String("repo")},
+	}
+}
+
+func TestOpen(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     Key
+		wantErr bool
+	}{
+		{
+			name: "Valid key",
+			key:  testKey,
+		},
+		{
+			name:    "Missing workflow",
+			key:     Key{Host: "github.com", Org: "owner", Repo: "repo"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Open(t.TempDir(), tt.key)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, s.Runs())
+		})
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s, err := Open(dir, testKey)
+	require.NoError(t, err)
+
+	completed := run(1, 1, "completed", now)
+	inProgress := run(2, 1, "in_progress", now.Add(time.Hour))
+	s.PutRuns([]*github.WorkflowRun{completed, inProgress})
+	s.PutJobs([]*github.WorkflowRun{completed, inProgress}, []*github.WorkflowJob{
+		{ID: github.Int64(10), RunID: github.Int64(1), RunAttempt: github.Int64(1)},
+		{ID: github.Int64(20), RunID: github.Int64(2), RunAttempt: github.Int64(1)},
+	})
+	s.SetWatermark("q", Watermark{CreatedAt: now, All: true})
+	require.NoError(t, s.Save())
+
+	_, err = os.Stat(filepath.Join(dir, "github.com", "owner", "repo", "ci.yaml.json"))
+	require.NoError(t, err)
+
+	loaded, err := Open(dir, testKey)
+	require.NoError(t, err)
+
+	runs := loaded.Runs()
+	require.Len(t, runs, 1, "only completed runs are cached")
+	assert.Equal(t, int64(1), runs[0].GetID())
+	assert.Nil(t, runs[0].Repository, "repository objects are not cached")
+	assert.NotNil(t, completed.Repository, "cached runs do not modify the input")
+
+	jobs, ok := loaded.Jobs(completed)
+	assert.True(t, ok)
+	assert.Len(t, jobs, 1)
+	_, ok = loaded.Jobs(inProgress)
+	assert.False(t, ok, "jobs of runs in progress are not cached")
+
+	wm, ok := loaded.Watermark("q")
+	assert.True(t, ok)
+	assert.True(t, wm.All)
+	assert.True(t, now.Equal(wm.CreatedAt))
+
+	loaded.Reset()
+	assert.Empty(t, loaded.Runs())
+	_, ok = loaded.Watermark("q")
+	assert.False(t, ok)
+}
+
+func TestOpenCorruptedFile(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir, testKey)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(s.Path()), 0o755))
+	require.NoError(t, os.WriteFile(s.Path(), []byte("{not json"), 0o600))
+
+	s, err = Open(dir, testKey)
+	require.NoError(t, err)
+	assert.Empty(t, s.Runs())
+}
+
+func TestNextWatermark(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		runs   []*github.WorkflowRun
+		want   time.Time
+		wantOK bool
+	}{
+		{
+			name: "Empty",
+			runs: []*github.WorkflowRun{},
+		},
+		{
+			name: "All completed",
+			runs: []*github.WorkflowRun{
+				run(1, 1, "completed", base),
+				run(2, 1, "completed", base.Add(2*time.Hour)),
+				run(3, 1, "completed", base.Add(time.Hour)),
+			},
+			want:   base.Add(2 * time.Hour),
+			wantOK: true,
+		},
+		{
+			name: "Oldest pending run wins",
+			runs: []*github.WorkflowRun{
+				run(1, 1, "completed", base),
+				run(2, 1, "in_progress", base.Add(2*time.Hour)),
+				run(3, 1, "queued", base.Add(time.Hour)),
+				run(4, 1, "completed", base.Add(3*time.Hour)),
+			},
+			want:   base.Add(time.Hour),
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NextWatermark(tt.runs)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
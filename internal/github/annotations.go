@@ -0,0 +1,68 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// FetchJobAnnotations fetches the check run annotations of the jobs, keyed by job ID. Jobs without annotations are skipped.
+func (c *WorkflowStatsClient) FetchJobAnnotations(ctx context.Context, cfg *WorkflowRunsConfig, jobs []*github.WorkflowJob) (map[int64][]*github.CheckRunAnnotation, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("GitHub client not initialized")
+	}
+
+	c.logger.Info("starting job annotations fetch",
+		"org", cfg.Org,
+		"repo", cfg.Repo,
+		"jobs_count", len(jobs),
+	)
+
+	annotations, err := fetchPerJob(jobs, func(job *github.WorkflowJob) ([]*github.CheckRunAnnotation, bool, error) {
+		as, err := c.fetchCheckRunAnnotations(ctx, cfg, checkRunID(job))
+		return as, len(as) > 0, err
+	})
+
+	c.logger.Info("completed job annotations fetch",
+		"total_jobs", len(jobs),
+		"annotated_jobs", len(annotations),
+	)
+	return annotations, err
+}
+
+// fetchCheckRunAnnotations fetches every annotation of a check run. No annotation is returned when the check run is not found.
+func (c *WorkflowStatsClient) fetchCheckRunAnnotations(ctx context.Context, cfg *WorkflowRunsConfig, id int64) ([]*github.CheckRunAnnotation, error) {
+	annotations := []*github.CheckRunAnnotation{}
+	opt := &github.ListOptions{PerPage: perPage}
+	for {
+		as, resp, err := c.client.Checks.ListCheckRunAnnotations(ctx, cfg.Org, cfg.Repo, id, opt)
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				c.logger.Debug("check run not found, skipping", "check_run_id", id)
+				return nil, nil
+			}
+			return annotations, c.handleHTTPError(resp, err, "list_check_run_annotations", fmt.Sprintf("repos/%s/%s/check-runs/%d/annotations", cfg.Org, cfg.Repo, id))
+		}
+		annotations = append(annotations, as...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return annotations, nil
+}
+
+// checkRunID returns the ID of the check run of a job. It is the ID of the job unless the check run URL tells otherwise.
+func checkRunID(job *github.WorkflowJob) int64 {
+	if id, err := strconv.ParseInt(path.Base(job.GetCheckRunURL()), 10, 64); err == nil {
+		return id
+	}
+	return job.GetID()
+}
@@ -0,0 +1,39 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchJobAnnotations(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/check-runs/10/annotations", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = fmt.Fprint(w, `[{"annotation_level": "failure", "message": "Process completed with exit code 1."}]`)
+			return
+		}
+		w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/check-runs/10/annotations?page=2>; rel="next"`)
+		_, _ = fmt.Fprint(w, `[{"annotation_level": "failure", "message": "Tests failed"}]`)
+	})
+	mux.HandleFunc("/repos/owner/repo/check-runs/2/annotations", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `[]`)
+	})
+	client := newTestClient(t, mux)
+
+	annotations, err := client.FetchJobAnnotations(context.Background(), &WorkflowRunsConfig{Org: "owner", Repo: "repo"}, []*github.WorkflowJob{
+		{ID: github.Int64(1), CheckRunURL: github.String("https://api.github.com/repos/owner/repo/check-runs/10")},
+		{ID: github.Int64(2)},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, annotations, 1)
+	require.Len(t, annotations[1], 2)
+	assert.Equal(t, "Tests failed", annotations[1][0].GetMessage())
+	assert.Equal(t, "Process completed with exit code 1.", annotations[1][1].GetMessage())
+}
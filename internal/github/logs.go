@@ -0,0 +1,190 @@
+package github
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/types"
+	"github.com/google/go-github/v60/github"
+)
+
+const (
+	// maxLogRedirects is the number of redirects followed to the download URL of a job log
+	maxLogRedirects = 10
+	// logDownloadTimeout bounds the download of a job log
+	logDownloadTimeout = 2 * time.Minute
+	// logContextLines is the number of lines kept before and after an error line.
+	// It covers the excerpt of the failure and the last output of a command that exits with an error.
+	logContextLines = 10
+	// maxLogErrorLines is the number of error lines of a job log after which the rest of the log is not read
+	maxLogErrorLines = 20
+	// maxLogLineLength truncates the lines of a job log
+	maxLogLineLength = 4096
+)
+
+// logHTTPClient downloads the job logs. The download URL is signed, the token of the API client must not be sent to it.
+var logHTTPClient = &http.Client{Timeout: logDownloadTimeout}
+
+// FetchJobLogs downloads the logs of the jobs, keyed by job ID. Jobs whose logs are not found or expired are skipped.
+func (c *WorkflowStatsClient) FetchJobLogs(ctx context.Context, cfg *WorkflowRunsConfig, jobs []*github.WorkflowJob) (map[int64]string, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("GitHub client not initialized")
+	}
+
+	c.logger.Info("starting job logs fetch",
+		"org", cfg.Org,
+		"repo", cfg.Repo,
+		"jobs_count", len(jobs),
+	)
+
+	logs, err := fetchPerJob(jobs, func(job *github.WorkflowJob) (string, bool, error) {
+		log, err := c.fetchJobLog(ctx, cfg, job.GetID())
+		return log, log != "", err
+	})
+
+	c.logger.Info("completed job logs fetch",
+		"total_jobs", len(jobs),
+		"total_logs", len(logs),
+	)
+	return logs, err
+}
+
+// fetchJobLog downloads the log of a job. An empty log is returned when the log is not found or expired.
+func (c *WorkflowStatsClient) fetchJobLog(ctx context.Context, cfg *WorkflowRunsConfig, jobID int64) (string, error) {
+	u, resp, err := c.client.Actions.GetWorkflowJobLogs(ctx, cfg.Org, cfg.Repo, jobID, maxLogRedirects)
+	if err != nil {
+		if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone) {
+			c.logger.Debug("job log not found, skipping", "job_id", jobID, "status_code", resp.StatusCode)
+			return "", nil
+		}
+		return "", c.handleHTTPError(resp, err, "get_job_logs", fmt.Sprintf("repos/%s/%s/actions/jobs/%d/logs", cfg.Org, cfg.Repo, jobID))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	res, err := logHTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		c.logger.Debug("job log download failed, skipping", "job_id", jobID, "status_code", res.StatusCode)
+		return "", nil
+	}
+
+	return scanErrorLines(res.Body)
+}
+
+// scanErrorLines reads a job log and keeps the error lines and the lines around them only, so that large logs are not held in memory.
+// Lines longer than maxLogLineLength are truncated and the log is not read further than maxLogErrorLines error lines.
+func scanErrorLines(r io.Reader) (string, error) {
+	var (
+		b      strings.Builder
+		before []string
+		after  int
+		errs   int
+	)
+	br := bufio.NewReaderSize(r, maxLogLineLength)
+	for errs < maxLogErrorLines || after > 0 {
+		line, err := readLogLine(br)
+		if err == io.EOF && line == "" {
+			break
+		}
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+
+		switch {
+		case errs < maxLogErrorLines && strings.Contains(line, types.LogErrorMarker):
+			for _, l := range before {
+				b.WriteString(l + "\n")
+			}
+			before = before[:0]
+			b.WriteString(line + "\n")
+			after = logContextLines
+			errs++
+		case after > 0:
+			b.WriteString(line + "\n")
+			after--
+		default:
+			if len(before) == logContextLines {
+				before = before[1:]
+			}
+			before = append(before, line)
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+	return b.String(), nil
+}
+
+// readLogLine reads a line without its line break. The part of the line beyond maxLogLineLength is discarded.
+func readLogLine(br *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		chunk, isPrefix, err := br.ReadLine()
+		if err != nil {
+			return string(line), err
+		}
+		if n := maxLogLineLength - len(line); n > 0 {
+			line = append(line, chunk[:min(len(chunk), n)]...)
+		}
+		if !isPrefix {
+			return string(line), nil
+		}
+	}
+}
+
+// fetchPerJob calls fetch for every job concurrently and collects the found results by job ID.
+// A rate limit error is reported over the other errors.
+func fetchPerJob[T any](jobs []*github.WorkflowJob, fetch func(job *github.WorkflowJob) (T, bool, error)) (map[int64]T, error) {
+	var (
+		mu       sync.Mutex
+		results  = make(map[int64]T, len(jobs))
+		firstErr error
+	)
+	sem := make(chan struct{}, runtime.NumCPU()*2)
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		if job == nil {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(job *github.WorkflowJob) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			r, found, err := fetch(job)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if _, ok := err.(*RateLimitError); ok || firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			if found {
+				results[job.GetID()] = r
+			}
+		}(job)
+	}
+	wg.Wait()
+	return results, firstErr
+}
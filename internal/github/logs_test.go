@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchJobLogs(t *testing.T) {
+	var client *WorkflowStatsClient
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/actions/jobs/1/logs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", client.client.BaseURL.String()+"download/1")
+		w.WriteHeader(http.StatusFound)
+	})
+	mux.HandleFunc("/repos/owner/repo/actions/jobs/2/logs", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Gone"}`, http.StatusGone)
+	})
+	mux.HandleFunc("/download/1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		_, _ = fmt.Fprint(w, "##[error]boom\n")
+	})
+	client = newTestClient(t, mux)
+
+	logs, err := client.FetchJobLogs(context.Background(), &WorkflowRunsConfig{Org: "owner", Repo: "repo"}, []*github.WorkflowJob{
+		{ID: github.Int64(1)},
+		{ID: github.Int64(2)},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]string{1: "##[error]boom\n"}, logs)
+}
+
+func TestScanErrorLines(t *testing.T) {
+	lines := func(prefix string, n int) string {
+		var b strings.Builder
+		for i := 1; i <= n; i++ {
+			fmt.Fprintf(&b, "%s %d\n", prefix, i)
+		}
+		return b.String()
+	}
+
+	tests := []struct {
+		name string
+		log  string
+		want string
+	}{
+		{
+			name: "No error",
+			log:  lines("line", 30),
+			want: "",
+		},
+		{
+			name: "Context around the error",
+			log:  lines("before", 15) + "##[error]boom\n" + lines("after", 15),
+			want: lines("before", 15)[len(lines("before", 5)):] + "##[error]boom\n" + lines("after", 10),
+		},
+		{
+			name: "Without a trailing line break",
+			log:  "output\n##[error]boom",
+			want: "output\n##[error]boom\n",
+		},
+		{
+			name: "Long lines are truncated",
+			log:  "##[error]" + strings.Repeat("x", 2*maxLogLineLength) + "\nnext\n",
+			want: "##[error]" + strings.Repeat("x", maxLogLineLength-len("##[error]")) + "\nnext\n",
+		},
+		{
+			name: "Stops after the maximum number of error lines",
+			log:  strings.Repeat("##[error]boom\n", maxLogErrorLines+logContextLines+5),
+			want: strings.Repeat("##[error]boom\n", maxLogErrorLines+logContextLines),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scanErrorLines(strings.NewReader(tt.log))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
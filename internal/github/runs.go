@@ -464,3 +464,32 @@ func (c *WorkflowStatsClient) listWorkflowRuns(ctx context.Context, cfg *Workflo
 		return c.client.Actions.ListWorkflowRunsByID(ctx, cfg.Org, cfg.Repo, cfg.WorkflowID, opt)
 	}
 }
+
+// FetchWorkflowRunAttempt fetches an attempt of a workflow run. Attempt 0 fetches the latest attempt.
+func (c *WorkflowStatsClient) FetchWorkflowRunAttempt(ctx context.Context, cfg *WorkflowRunsConfig, runID int64, attempt int) (*github.WorkflowRun, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("GitHub client not initialized")
+	}
+
+	c.logger.Info("starting workflow run fetch",
+		"org", cfg.Org,
+		"repo", cfg.Repo,
+		"run_id", runID,
+		"run_attempt", attempt,
+	)
+
+	var (
+		run  *github.WorkflowRun
+		resp *github.Response
+		err  error
+	)
+	if attempt > 0 {
+		run, resp, err = c.client.Actions.GetWorkflowRunAttempt(ctx, cfg.Org, cfg.Repo, runID, attempt, nil)
+	} else {
+		run, resp, err = c.client.Actions.GetWorkflowRunByID(ctx, cfg.Org, cfg.Repo, runID)
+	}
+	if err != nil {
+		return nil, c.handleHTTPError(resp, err, "get_workflow_run", fmt.Sprintf("repos/%s/%s/actions/runs/%d", cfg.Org, cfg.Repo, runID))
+	}
+	return run, nil
+}
@@ -1,11 +1,15 @@
 package github
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/google/go-github/v60/github"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateListOptions(t *testing.T) {
@@ -344,3 +348,43 @@ func TestFetchWorkflowJobsAttempts_InputValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchWorkflowRunAttempt(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/actions/runs/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id": 1, "run_attempt": 3}`)
+	})
+	mux.HandleFunc("/repos/owner/repo/actions/runs/1/attempts/2", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id": 1, "run_attempt": 2}`)
+	})
+	mux.HandleFunc("/repos/owner/repo/actions/runs/2", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	})
+	client := newTestClient(t, mux)
+	cfg := &WorkflowRunsConfig{Org: "owner", Repo: "repo"}
+
+	tests := []struct {
+		name        string
+		runID       int64
+		attempt     int
+		wantAttempt int
+		wantErr     bool
+	}{
+		{name: "latest attempt", runID: 1, attempt: 0, wantAttempt: 3},
+		{name: "given attempt", runID: 1, attempt: 2, wantAttempt: 2},
+		{name: "not found", runID: 2, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run, err := client.FetchWorkflowRunAttempt(context.Background(), cfg, tt.runID, tt.attempt)
+			if tt.wantErr {
+				var apiErr *APIError
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAttempt, run.GetRunAttempt())
+		})
+	}
+}
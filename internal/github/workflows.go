@@ -0,0 +1,64 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// FetchWorkflows fetches every workflow of a repository
+func (c *WorkflowStatsClient) FetchWorkflows(ctx context.Context, org, repo string) ([]*github.Workflow, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("GitHub client not initialized")
+	}
+
+	c.logger.Info("starting workflows fetch", "org", org, "repo", repo)
+
+	workflows := []*github.Workflow{}
+	opt := &github.ListOptions{PerPage: perPage}
+	for {
+		wfs, resp, err := c.client.Actions.ListWorkflows(ctx, org, repo, opt)
+		if err != nil {
+			return workflows, c.handleHTTPError(resp, err, "list_workflows", fmt.Sprintf("repos/%s/%s/actions/workflows", org, repo))
+		}
+		if wfs != nil {
+			workflows = append(workflows, wfs.Workflows...)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	c.logger.Info("completed workflows fetch", "org", org, "repo", repo, "total_workflows", len(workflows))
+	return workflows, nil
+}
+
+// FetchRepositories fetches every repository of an organization
+func (c *WorkflowStatsClient) FetchRepositories(ctx context.Context, org string) ([]*github.Repository, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("GitHub client not initialized")
+	}
+
+	c.logger.Info("starting repositories fetch", "org", org)
+
+	repos := []*github.Repository{}
+	opt := &github.RepositoryListByOrgOptions{
+		ListOptions: github.ListOptions{PerPage: perPage},
+	}
+	for {
+		rs, resp, err := c.client.Repositories.ListByOrg(ctx, org, opt)
+		if err != nil {
+			return repos, c.handleHTTPError(resp, err, "list_repositories", fmt.Sprintf("orgs/%s/repos", org))
+		}
+		repos = append(repos, rs...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	c.logger.Info("completed repositories fetch", "org", org, "total_repositories", len(repos))
+	return repos, nil
+}
@@ -0,0 +1,85 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/logger"
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client that sends every request to the given handler
+func newTestClient(t *testing.T, handler http.Handler) *WorkflowStatsClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	u, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+	client.BaseURL = u
+
+	return &WorkflowStatsClient{
+		client: client,
+		logger: logger.NewNoOpLogger(),
+	}
+}
+
+func TestFetchWorkflows(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/actions/workflows", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = fmt.Fprint(w, `{"total_count": 2, "workflows": [{"id": 2, "name": "Release", "path": ".github/workflows/release.yml"}]}`)
+			return
+		}
+		w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/actions/workflows?page=2>; rel="next"`)
+		_, _ = fmt.Fprint(w, `{"total_count": 2, "workflows": [{"id": 1, "name": "CI", "path": ".github/workflows/ci.yaml"}]}`)
+	})
+	client := newTestClient(t, mux)
+
+	workflows, err := client.FetchWorkflows(context.Background(), "owner", "repo")
+
+	require.NoError(t, err)
+	require.Len(t, workflows, 2)
+	assert.Equal(t, "CI", workflows[0].GetName())
+	assert.Equal(t, "Release", workflows[1].GetName())
+}
+
+func TestFetchWorkflows_Error(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+	}))
+
+	_, err := client.FetchWorkflows(context.Background(), "owner", "repo")
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
+
+func TestFetchRepositories(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/owner/repos", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = fmt.Fprint(w, `[{"id": 2, "name": "api", "topics": ["go"]}]`)
+			return
+		}
+		w.Header().Set("Link", `<https://api.github.com/orgs/owner/repos?page=2>; rel="next"`)
+		_, _ = fmt.Fprint(w, `[{"id": 1, "name": "web", "archived": true}]`)
+	})
+	client := newTestClient(t, mux)
+
+	repos, err := client.FetchRepositories(context.Background(), "owner")
+
+	require.NoError(t, err)
+	require.Len(t, repos, 2)
+	assert.Equal(t, "web", repos[0].GetName())
+	assert.True(t, repos[0].GetArchived())
+	assert.Equal(t, []string{"go"}, repos[1].Topics)
+}
@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"github.com/google/go-github/v60/github"
+)
+
+// Attempts of the workflow runs that feed the stats
+const (
+	// AttemptsFirst keeps the first attempt of every run
+	AttemptsFirst = "first"
+	// AttemptsLast keeps the latest attempt of every run
+	AttemptsLast = "last"
+	// AttemptsAll keeps every attempt, a re-run counts as another run
+	AttemptsAll = "all"
+)
+
+// IsValidAttempts reports whether attempts is a supported attempts selection
+func IsValidAttempts(attempts string) bool {
+	return attempts == AttemptsFirst || attempts == AttemptsLast || attempts == AttemptsAll
+}
+
+// AttemptRates compares the success rate of the first attempts, the latest attempts and every attempt of the runs.
+// A run that failed on its first attempt and succeeded on a re-run is a failure of FirstAttemptSuccessRate and a success of FinalSuccessRate.
+type AttemptRates struct {
+	RunsCount               int     `json:"runs_count"`
+	AttemptsCount           int     `json:"attempts_count"`
+	FirstAttemptSuccessRate float64 `json:"first_attempt_success_rate"`
+	FinalSuccessRate        float64 `json:"final_success_rate"`
+	PerAttemptSuccessRate   float64 `json:"per_attempt_success_rate"`
+}
+
+// calcAttemptRates computes the success rates of the first, latest and all attempts of the workflow runs.
+// Runs whose first attempt is missing are left out of FirstAttemptSuccessRate.
+func calcAttemptRates(wrs []*github.WorkflowRun) *AttemptRates {
+	ar := &AttemptRates{AttemptsCount: len(wrs)}
+	latest := latestAttempts(wrs)
+	ar.RunsCount = len(latest)
+
+	var firsts, firstSuccesses, finalSuccesses, successes int
+	for _, wr := range wrs {
+		success := wr.GetConclusion() == ConclusionSuccess
+		if success {
+			successes++
+		}
+		if wr.GetRunAttempt() <= 1 {
+			firsts++
+			if success {
+				firstSuccesses++
+			}
+		}
+		if latest[wr.GetID()] == wr.GetRunAttempt() && success {
+			finalSuccesses++
+		}
+	}
+
+	ar.FirstAttemptSuccessRate = float64(firstSuccesses) / max(float64(firsts), 1)
+	ar.FinalSuccessRate = float64(finalSuccesses) / max(float64(ar.RunsCount), 1)
+	ar.PerAttemptSuccessRate = float64(successes) / max(float64(ar.AttemptsCount), 1)
+	return ar
+}
+
+// filterRunAttempts keeps the attempts of the workflow runs selected by attempts. An empty selection keeps every attempt.
+func filterRunAttempts(wrs []*github.WorkflowRun, attempts string) []*github.WorkflowRun {
+	if attempts != AttemptsFirst && attempts != AttemptsLast {
+		return wrs
+	}
+	latest := latestAttempts(wrs)
+	filtered := make([]*github.WorkflowRun, 0, len(latest))
+	for _, wr := range wrs {
+		if keepAttempt(attempts, wr.GetRunAttempt(), latest[wr.GetID()]) {
+			filtered = append(filtered, wr)
+		}
+	}
+	return filtered
+}
+
+// filterJobAttempts keeps the jobs of the run attempts selected by attempts. An empty selection keeps every attempt.
+func filterJobAttempts(wjs []*github.WorkflowJob, attempts string) []*github.WorkflowJob {
+	if attempts != AttemptsFirst && attempts != AttemptsLast {
+		return wjs
+	}
+	latest := make(map[int64]int64)
+	for _, wj := range wjs {
+		latest[wj.GetRunID()] = max(latest[wj.GetRunID()], wj.GetRunAttempt())
+	}
+	filtered := make([]*github.WorkflowJob, 0, len(wjs))
+	for _, wj := range wjs {
+		if keepAttempt(attempts, int(wj.GetRunAttempt()), int(latest[wj.GetRunID()])) {
+			filtered = append(filtered, wj)
+		}
+	}
+	return filtered
+}
+
+// latestAttempts returns the latest attempt of every run by run ID
+func latestAttempts(wrs []*github.WorkflowRun) map[int64]int {
+	latest := make(map[int64]int)
+	for _, wr := range wrs {
+		latest[wr.GetID()] = max(latest[wr.GetID()], wr.GetRunAttempt())
+	}
+	return latest
+}
+
+func keepAttempt(attempts string, attempt, latest int) bool {
+	if attempts == AttemptsFirst {
+		return attempt <= 1
+	}
+	return attempt == latest
+}
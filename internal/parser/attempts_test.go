@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowRunsParse_Attempts(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	attempt := func(id int64, attempt int, conclusion string) *github.WorkflowRun {
+		wr := newRun(id, conclusion, start, time.Minute)
+		wr.RunAttempt = github.Int(attempt)
+		return wr
+	}
+	wrs := []*github.WorkflowRun{
+		attempt(1, 1, ConclusionSuccess),
+		attempt(2, 2, ConclusionSuccess),
+		attempt(2, 1, ConclusionFailure),
+		attempt(3, 1, ConclusionFailure),
+		attempt(3, 2, ConclusionFailure),
+		attempt(4, 1, ConclusionFailure),
+	}
+	rates := &AttemptRates{
+		RunsCount:               4,
+		AttemptsCount:           6,
+		FirstAttemptSuccessRate: 0.25,
+		FinalSuccessRate:        0.5,
+		PerAttemptSuccessRate:   1.0 / 3,
+	}
+
+	tests := []struct {
+		name             string
+		attempts         string
+		wantRates        *AttemptRates
+		wantRunsCount    int
+		wantSuccessCount int
+	}{
+		{name: "default", attempts: "", wantRates: nil, wantRunsCount: 6, wantSuccessCount: 2},
+		{name: "all", attempts: AttemptsAll, wantRates: rates, wantRunsCount: 6, wantSuccessCount: 2},
+		{name: "first", attempts: AttemptsFirst, wantRates: rates, wantRunsCount: 4, wantSuccessCount: 1},
+		{name: "last", attempts: AttemptsLast, wantRates: rates, wantRunsCount: 4, wantSuccessCount: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wfrss := WorkflowRunsParseWithOptions(wrs, ParseOptions{Attempts: tt.attempts})
+
+			assert.Equal(t, tt.wantRates, wfrss.AttemptRates)
+			assert.Equal(t, tt.wantRunsCount, wfrss.TotalRunsCount)
+			assert.Equal(t, tt.wantSuccessCount, wfrss.Conclusions[ConclusionSuccess].RunsCount)
+		})
+	}
+}
+
+func TestWorkflowJobsParse_Attempts(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := func(runID, attempt int64, conclusion string) *github.WorkflowJob {
+		wj := newJob("test", conclusion, start, time.Minute)
+		wj.RunID = github.Int64(runID)
+		wj.RunAttempt = github.Int64(attempt)
+		return wj
+	}
+	wjs := []*github.WorkflowJob{
+		job(1, 1, ConclusionFailure),
+		job(1, 2, ConclusionSuccess),
+		job(2, 1, ConclusionSuccess),
+	}
+
+	tests := []struct {
+		attempts    string
+		wantRuns    int
+		wantSuccess float64
+	}{
+		{attempts: AttemptsAll, wantRuns: 3, wantSuccess: 2.0 / 3},
+		{attempts: AttemptsFirst, wantRuns: 2, wantSuccess: 0.5},
+		{attempts: AttemptsLast, wantRuns: 2, wantSuccess: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.attempts, func(t *testing.T) {
+			jobs := WorkflowJobsParseWithOptions(wjs, ParseOptions{Attempts: tt.attempts})
+
+			assert.Len(t, jobs, 1)
+			assert.Equal(t, tt.wantRuns, jobs[0].TotalRunsCount)
+			assert.InDelta(t, tt.wantSuccess, jobs[0].Rate.SuccesRate, 1e-9)
+		})
+	}
+}
+
+func TestIsValidAttempts(t *testing.T) {
+	assert.True(t, IsValidAttempts("first"))
+	assert.True(t, IsValidAttempts("last"))
+	assert.True(t, IsValidAttempts("all"))
+	assert.False(t, IsValidAttempts(""))
+	assert.False(t, IsValidAttempts("latest"))
+}
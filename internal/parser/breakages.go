@@ -0,0 +1,201 @@
+package parser
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// BreakageSummary reports the breakages of a branch: the red streaks that started when a commit failed after a successful commit.
+// Failing commits before the first successful commit are ignored, the start of their breakage is unknown.
+type BreakageSummary struct {
+	Branch         string           `json:"branch"`
+	CommitsCount   int              `json:"commits_count"`
+	BreakagesCount int              `json:"breakages_count"`
+	Reliability    ReliabilityStats `json:"reliability"`
+	Breakages      []*Breakage      `json:"breakages"`
+	Actors         []*BreakageActor `json:"actors"`
+}
+
+// ReliabilityStats are DORA-style reliability metrics of a branch, from the push of its first green commit to the end of the analyzed period.
+// Times are in seconds. The time to recovery is the duration of the fixed breakages, the longest outage includes an ongoing one.
+type ReliabilityStats struct {
+	Since                time.Time `json:"since"`
+	Period               float64   `json:"period"`
+	RedTime              float64   `json:"red_time"`
+	RedTimeRate          float64   `json:"red_time_rate"`
+	RedStreaksCount      int       `json:"red_streaks_count"`
+	MeanTimeToRecovery   float64   `json:"mean_time_to_recovery"`
+	MedianTimeToRecovery float64   `json:"median_time_to_recovery"`
+	LongestOutage        float64   `json:"longest_outage"`
+}
+
+// Breakage is a red streak of a branch, from the first failing commit to the commit that fixed it.
+// Duration is the time in seconds from the push of the failing commit to the completion of the fixing run, or to the end of the analyzed period when the branch is still red.
+type Breakage struct {
+	SHA                string     `json:"sha"`
+	Actor              string     `json:"actor"`
+	StartedAt          time.Time  `json:"started_at"`
+	HTMLURL            string     `json:"html_url"`
+	FailedCommitsCount int        `json:"failed_commits_count"`
+	FixedSHA           string     `json:"fixed_sha,omitempty"`
+	FixedBy            string     `json:"fixed_by,omitempty"`
+	FixedAt            *time.Time `json:"fixed_at,omitempty"`
+	Ongoing            bool       `json:"ongoing"`
+	Duration           float64    `json:"duration"`
+}
+
+// BreakageActor is a user that pushed commits that broke the branch
+type BreakageActor struct {
+	Actor          string `json:"actor"`
+	BreakagesCount int    `json:"breakages_count"`
+}
+
+// commitOutcome is the final outcome of the runs of a commit
+type commitOutcome struct {
+	sha         string
+	actor       string
+	htmlURL     string
+	pushedAt    time.Time
+	completedAt time.Time
+	success     bool
+}
+
+// WorkflowBreakagesParse walks the commits of a branch in push order and reports the transitions from success to failure and back.
+// The outcome of a commit is the conclusion of its latest attempt, commits without a success or failure conclusion are skipped.
+// The analyzed period ends at until, or at the completion of the last run when until is zero.
+func WorkflowBreakagesParse(wrs []*github.WorkflowRun, branch string, until time.Time) *BreakageSummary {
+	bs := &BreakageSummary{
+		Branch:    branch,
+		Breakages: []*Breakage{},
+		Actors:    []*BreakageActor{},
+	}
+
+	commits := commitOutcomes(wrs)
+	bs.CommitsCount = len(commits)
+
+	var cur *Breakage
+	green := false
+	actors := make(map[string]int)
+	end := until
+	for _, c := range commits {
+		if until.IsZero() && c.completedAt.After(end) {
+			end = c.completedAt
+		}
+		switch {
+		case !c.success && cur != nil:
+			cur.FailedCommitsCount++
+		case !c.success && green:
+			cur = &Breakage{
+				SHA:                c.sha,
+				Actor:              c.actor,
+				StartedAt:          c.pushedAt,
+				HTMLURL:            c.htmlURL,
+				FailedCommitsCount: 1,
+			}
+			bs.Breakages = append(bs.Breakages, cur)
+			actors[c.actor]++
+		case c.success && cur != nil:
+			fixedAt := c.completedAt
+			cur.FixedSHA = c.sha
+			cur.FixedBy = c.actor
+			cur.FixedAt = &fixedAt
+			cur.Duration = fixedAt.Sub(cur.StartedAt).Seconds()
+			cur = nil
+		}
+		if c.success && !green {
+			green = true
+			bs.Reliability.Since = c.pushedAt
+		}
+	}
+	if cur != nil {
+		cur.Ongoing = true
+		cur.Duration = max(end.Sub(cur.StartedAt).Seconds(), 0)
+	}
+	bs.BreakagesCount = len(bs.Breakages)
+	if green {
+		bs.Reliability = reliabilityStats(bs.Breakages, bs.Reliability.Since, end)
+	}
+
+	// Latest breakage first
+	sort.SliceStable(bs.Breakages, func(i, j int) bool {
+		return bs.Breakages[i].StartedAt.After(bs.Breakages[j].StartedAt)
+	})
+	for a, n := range actors {
+		bs.Actors = append(bs.Actors, &BreakageActor{Actor: a, BreakagesCount: n})
+	}
+	sort.Slice(bs.Actors, func(i, j int) bool {
+		if bs.Actors[i].BreakagesCount != bs.Actors[j].BreakagesCount {
+			return bs.Actors[i].BreakagesCount > bs.Actors[j].BreakagesCount
+		}
+		return bs.Actors[i].Actor < bs.Actors[j].Actor
+	})
+	return bs
+}
+
+// reliabilityStats computes the reliability metrics of the breakages of a branch from since, its first green commit, to end
+func reliabilityStats(breakages []*Breakage, since, end time.Time) ReliabilityStats {
+	rs := ReliabilityStats{
+		Since:           since,
+		Period:          max(end.Sub(since).Seconds(), 0),
+		RedStreaksCount: len(breakages),
+	}
+	recoveries := make([]float64, 0, len(breakages))
+	for _, b := range breakages {
+		rs.RedTime += b.Duration
+		rs.LongestOutage = max(rs.LongestOutage, b.Duration)
+		if !b.Ongoing {
+			recoveries = append(recoveries, b.Duration)
+		}
+	}
+	if rs.Period > 0 {
+		rs.RedTimeRate = rs.RedTime / rs.Period
+	}
+	if len(recoveries) > 0 {
+		s := calcStats(recoveries)
+		rs.MeanTimeToRecovery = s.Avg
+		rs.MedianTimeToRecovery = s.Med
+	}
+	return rs
+}
+
+// commitOutcomes returns the outcome of every commit with a completed success or failure run, in push order.
+// The outcome of a commit is the attempt that started last.
+func commitOutcomes(wrs []*github.WorkflowRun) []*commitOutcome {
+	latest := make(map[string]*github.WorkflowRun)
+	pushedAt := make(map[string]time.Time)
+	for _, wr := range wrs {
+		sha := wr.GetHeadSHA()
+		created := wr.GetCreatedAt().UTC()
+		if t, ok := pushedAt[sha]; !ok || created.Before(t) {
+			pushedAt[sha] = created
+		}
+		if l, ok := latest[sha]; !ok || wr.GetRunStartedAt().After(l.GetRunStartedAt().Time) {
+			latest[sha] = wr
+		}
+	}
+
+	commits := make([]*commitOutcome, 0, len(latest))
+	for sha, wr := range latest {
+		c := wr.GetConclusion()
+		if wr.GetStatus() != StatusCompleted || (c != ConclusionSuccess && c != ConclusionFailure) {
+			continue
+		}
+		commits = append(commits, &commitOutcome{
+			sha:         sha,
+			actor:       wr.GetActor().GetLogin(),
+			htmlURL:     wr.GetHTMLURL(),
+			pushedAt:    pushedAt[sha],
+			completedAt: wr.GetUpdatedAt().UTC(),
+			success:     c == ConclusionSuccess,
+		})
+	}
+	sort.Slice(commits, func(i, j int) bool {
+		if !commits[i].pushedAt.Equal(commits[j].pushedAt) {
+			return commits[i].pushedAt.Before(commits[j].pushedAt)
+		}
+		return commits[i].sha < commits[j].sha
+	})
+	return commits
+}
@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowBreakagesParse(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	hour := func(h int) time.Time { return base.Add(time.Duration(h) * time.Hour) }
+	run := func(sha, actor, conclusion string, attempt int, created, started time.Time) *github.WorkflowRun {
+		wr := newRun(int64(len(sha)), conclusion, started, 10*time.Minute)
+		wr.HeadSHA = github.String(sha)
+		wr.Actor = &github.User{Login: github.String(actor)}
+		wr.RunAttempt = github.Int(attempt)
+		wr.CreatedAt = &github.Timestamp{Time: created}
+		wr.HTMLURL = github.String("https://example.com/" + sha)
+		return wr
+	}
+
+	wrs := []*github.WorkflowRun{
+		run("c6", "carol", ConclusionFailure, 1, hour(5), hour(5)),
+		run("c0", "dave", ConclusionFailure, 1, hour(-1), hour(-1)),
+		run("c1", "bob", ConclusionSuccess, 1, hour(0), hour(0)),
+		run("c2", "alice", ConclusionFailure, 1, hour(1), hour(1)),
+		run("c3", "bob", ConclusionFailure, 1, hour(2), hour(2)),
+		run("cx", "bob", "cancelled", 1, hour(2), hour(2)),
+		run("c4", "bob", ConclusionSuccess, 1, hour(3), hour(3)),
+		run("c5", "alice", ConclusionFailure, 1, hour(4), hour(4)),
+		run("c5", "alice", ConclusionSuccess, 2, hour(4), hour(4).Add(30*time.Minute)),
+	}
+
+	bs := WorkflowBreakagesParse(wrs, "main", hour(7))
+
+	assert.Equal(t, "main", bs.Branch)
+	assert.Equal(t, 7, bs.CommitsCount)
+	assert.Equal(t, 2, bs.BreakagesCount)
+	require.Len(t, bs.Breakages, 2)
+	assert.Equal(t, &Breakage{
+		SHA:                "c6",
+		Actor:              "carol",
+		StartedAt:          hour(5),
+		HTMLURL:            "https://example.com/c6",
+		FailedCommitsCount: 1,
+		Ongoing:            true,
+		Duration:           (2 * time.Hour).Seconds(),
+	}, bs.Breakages[0])
+	fixedAt := hour(3).Add(10 * time.Minute)
+	assert.Equal(t, &Breakage{
+		SHA:                "c2",
+		Actor:              "alice",
+		StartedAt:          hour(1),
+		HTMLURL:            "https://example.com/c2",
+		FailedCommitsCount: 2,
+		FixedSHA:           "c4",
+		FixedBy:            "bob",
+		FixedAt:            &fixedAt,
+		Duration:           (2*time.Hour + 10*time.Minute).Seconds(),
+	}, bs.Breakages[1])
+	assert.Equal(t, []*BreakageActor{{Actor: "alice", BreakagesCount: 1}, {Actor: "carol", BreakagesCount: 1}}, bs.Actors)
+
+	r := bs.Reliability
+	assert.Equal(t, hour(0), r.Since)
+	assert.Equal(t, (7 * time.Hour).Seconds(), r.Period)
+	assert.Equal(t, (4*time.Hour + 10*time.Minute).Seconds(), r.RedTime)
+	assert.InDelta(t, 250.0/420, r.RedTimeRate, 1e-9)
+	assert.Equal(t, 2, r.RedStreaksCount)
+	assert.Equal(t, (2*time.Hour + 10*time.Minute).Seconds(), r.MeanTimeToRecovery)
+	assert.Equal(t, (2*time.Hour + 10*time.Minute).Seconds(), r.MedianTimeToRecovery)
+	assert.Equal(t, (2*time.Hour + 10*time.Minute).Seconds(), r.LongestOutage)
+}
+
+func TestWorkflowBreakagesParse_EndsAtLastRun(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	run := func(sha, conclusion string, created time.Time) *github.WorkflowRun {
+		wr := newRun(int64(len(sha)), conclusion, created, 10*time.Minute)
+		wr.HeadSHA = github.String(sha)
+		return wr
+	}
+	wrs := []*github.WorkflowRun{
+		run("c1", ConclusionSuccess, base),
+		run("c2", ConclusionFailure, base.Add(time.Hour)),
+		run("c3", ConclusionFailure, base.Add(2*time.Hour)),
+	}
+
+	bs := WorkflowBreakagesParse(wrs, "main", time.Time{})
+
+	require.Len(t, bs.Breakages, 1)
+	assert.True(t, bs.Breakages[0].Ongoing)
+	assert.Equal(t, (time.Hour + 10*time.Minute).Seconds(), bs.Breakages[0].Duration)
+	assert.Equal(t, (2*time.Hour + 10*time.Minute).Seconds(), bs.Reliability.Period)
+}
+
+func TestReliabilityStats(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	breakages := []*Breakage{
+		{Duration: 3600},
+		{Duration: 600},
+		{Duration: 1200},
+		{Duration: 7200, Ongoing: true},
+	}
+
+	r := reliabilityStats(breakages, since, since.Add(24*time.Hour))
+
+	assert.Equal(t, ReliabilityStats{
+		Since:                since,
+		Period:               86400,
+		RedTime:              12600,
+		RedTimeRate:          12600.0 / 86400,
+		RedStreaksCount:      4,
+		MeanTimeToRecovery:   1800,
+		MedianTimeToRecovery: 1200,
+		LongestOutage:        7200,
+	}, r)
+}
+
+func TestWorkflowBreakagesParse_Empty(t *testing.T) {
+	assert.Equal(t, &BreakageSummary{Branch: "main", Breakages: []*Breakage{}, Actors: []*BreakageActor{}}, WorkflowBreakagesParse(nil, "main", time.Now()))
+}
@@ -2,23 +2,60 @@ package parser
 
 import (
 	"slices"
+	"sort"
+	"strconv"
 
+	"github.com/google/go-github/v60/github"
 	"github.com/montanaflynn/stats"
 )
 
 const eps = 1e-9
 
 type ExecutionDurationStats struct {
-	Min float64 `json:"min"`
-	Max float64 `json:"max"`
-	Avg float64 `json:"avg"`
-	Med float64 `json:"med"`
-	Std float64 `json:"std"`
+	Min         float64      `json:"min"`
+	Max         float64      `json:"max"`
+	Avg         float64      `json:"avg"`
+	Med         float64      `json:"med"`
+	Std         float64      `json:"std"`
+	Percentiles []Percentile `json:"percentiles,omitempty"`
 }
 
-func calcStats(d []float64) ExecutionDurationStats {
+type Percentile struct {
+	P     float64 `json:"p"`
+	Value float64 `json:"value"`
+}
+
+// Label returns the name of the percentile, e.g. p95 or p99.9
+func (p Percentile) Label() string {
+	return "p" + strconv.FormatFloat(p.P, 'f', -1, 64)
+}
+
+// ParseOptions configures the stats computed by the parsers
+type ParseOptions struct {
+	// Percentiles of the execution durations to compute, between 0 and 100
+	Percentiles []float64
+	// DurationMode selects how the duration of a workflow run is measured. Defaults to DurationModeUpdated.
+	// With DurationModeJobs the sum of the execution times of the jobs of every run is reported too.
+	DurationMode string
+	// Jobs of the workflow runs, required by DurationModeJobs
+	Jobs []*github.WorkflowJob
+	// Attempts selects the attempts of the runs that feed the stats, one of AttemptsFirst, AttemptsLast or AttemptsAll.
+	// When set, the success rates of the first, latest and all attempts are reported too. Defaults to every attempt.
+	Attempts string
+	// GroupBy are the dimensions the jobs stats are grouped by, e.g. GroupByJob and GroupByRunnerName. Defaults to the job name.
+	GroupBy []string
+	// Matrix rolls the matrix legs of a job, e.g. "build (ubuntu-latest)", up under their base job "build"
+	Matrix bool
+	// MatrixAxis pivots the jobs stats by the matrix axis values matching the glob pattern, e.g. "windows-*".
+	// The legs of an axis value are its base jobs.
+	MatrixAxis string
+	// HistogramBuckets is the number of buckets of the duration histograms of the runs and jobs. No histogram when 0.
+	HistogramBuckets int
+}
+
+func calcStats(d []float64, percentiles ...float64) ExecutionDurationStats {
 	if len(d) == 0 {
-		return ExecutionDurationStats{}
+		return ExecutionDurationStats{Percentiles: calcPercentiles(nil, percentiles)}
 	}
 	min := slices.Min(d)
 	max := slices.Max(d)
@@ -27,15 +64,32 @@ func calcStats(d []float64) ExecutionDurationStats {
 	std, _ := stats.StandardDeviation(d)
 
 	return ExecutionDurationStats{
-		Min: min,
-		Max: max,
-		Avg: avg,
-		Med: med,
-		Std: std,
+		Min:         min,
+		Max:         max,
+		Avg:         avg,
+		Med:         med,
+		Std:         std,
+		Percentiles: calcPercentiles(d, percentiles),
 	}
 
 }
 
+// calcPercentiles interpolates the percentiles of d. It returns nil when no percentile is requested.
+func calcPercentiles(d []float64, percentiles []float64) []Percentile {
+	if len(percentiles) == 0 {
+		return nil
+	}
+	sorted := make([]float64, len(d))
+	copy(sorted, d)
+	sort.Float64s(sorted)
+
+	res := make([]Percentile, 0, len(percentiles))
+	for _, p := range percentiles {
+		res = append(res, Percentile{P: p, Value: calculatePercentile(sorted, p)})
+	}
+	return res
+}
+
 func adjustRate(rate float64) float64 {
 	if rate < eps {
 		return 0
@@ -132,3 +132,56 @@ func TestAdjustRate(t *testing.T) {
 func TestEpsConstant(t *testing.T) {
 	assert.Equal(t, 1e-9, eps, "Epsilon constant should be 1e-9")
 }
+
+func TestCalcStats_Percentiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       []float64
+		percentiles []float64
+		expected    []Percentile
+	}{
+		{
+			name:        "No percentiles",
+			input:       []float64{1, 2, 3},
+			percentiles: nil,
+			expected:    nil,
+		},
+		{
+			name:        "Empty input",
+			input:       []float64{},
+			percentiles: []float64{95},
+			expected:    []Percentile{{P: 95, Value: 0}},
+		},
+		{
+			name:        "Interpolated",
+			input:       []float64{50, 10, 40, 20, 30},
+			percentiles: []float64{50, 90, 95, 99},
+			expected:    []Percentile{{P: 50, Value: 30}, {P: 90, Value: 46}, {P: 95, Value: 48}, {P: 99, Value: 49.6}},
+		},
+		{
+			name:        "Bounds",
+			input:       []float64{3, 1, 2},
+			percentiles: []float64{0, 100},
+			expected:    []Percentile{{P: 0, Value: 1}, {P: 100, Value: 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append([]float64{}, tt.input...)
+			result := calcStats(tt.input, tt.percentiles...)
+
+			assert.Len(t, result.Percentiles, len(tt.expected))
+			for i, p := range tt.expected {
+				assert.Equal(t, p.P, result.Percentiles[i].P)
+				assert.InDelta(t, p.Value, result.Percentiles[i].Value, 1e-9)
+			}
+			assert.Equal(t, input, tt.input, "input must not be sorted in place")
+		})
+	}
+}
+
+func TestPercentileLabel(t *testing.T) {
+	assert.Equal(t, "p95", Percentile{P: 95}.Label())
+	assert.Equal(t, "p99.9", Percentile{P: 99.9}.Label())
+}
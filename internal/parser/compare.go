@@ -0,0 +1,220 @@
+package parser
+
+import (
+	"sort"
+
+	"github.com/google/go-github/v60/github"
+)
+
+const (
+	DeltaKindWorkflow = "workflow"
+	DeltaKindJob      = "job"
+	DeltaKindStep     = "step"
+
+	// DefaultSignificanceLevel is the p-value below which a change is reported as significant
+	DefaultSignificanceLevel = 0.05
+)
+
+// ComparisonInput is one side of a comparison
+type ComparisonInput struct {
+	Label string
+	Runs  []*github.WorkflowRun
+	Jobs  []*github.WorkflowJob
+}
+
+type ComparisonSummary struct {
+	Alpha float64           `json:"alpha"`
+	Base  *ComparisonSide   `json:"base"`
+	Head  *ComparisonSide   `json:"head"`
+	Diff  []*ComparisonDiff `json:"diff"`
+}
+
+type ComparisonSide struct {
+	Label                    string                      `json:"label"`
+	WorkflowRunsStatsSummary *WorkflowRunsStatsSummary   `json:"workflow_runs_stats_summary"`
+	WorkflowJobsStatsSummary []*WorkflowJobsStatsSummary `json:"workflow_jobs_stats_summary"`
+}
+
+// ComparisonDiff is the change of the success rate and the duration of a workflow, a job or a step from base to head
+type ComparisonDiff struct {
+	Kind                   string           `json:"kind"`
+	Name                   string           `json:"name"`
+	Job                    string           `json:"job,omitempty"`
+	Base                   ComparisonSample `json:"base"`
+	Head                   ComparisonSample `json:"head"`
+	SuccessRateDelta       float64          `json:"success_rate_delta"`
+	SuccessRatePValue      float64          `json:"success_rate_p_value"`
+	SuccessRateSignificant bool             `json:"success_rate_significant"`
+	MedDelta               float64          `json:"med_delta"`
+	P95Delta               float64          `json:"p95_delta"`
+	DurationPValue         float64          `json:"duration_p_value"`
+	DurationSignificant    bool             `json:"duration_significant"`
+}
+
+type ComparisonSample struct {
+	RunsCount      int     `json:"runs_count"`
+	SuccesRate     float64 `json:"success_rate"`
+	Med            float64 `json:"med"`
+	P95            float64 `json:"p95"`
+	DurationsCount int     `json:"durations_count"`
+}
+
+// sample holds the raw values of a workflow, a job or a step on one side of a comparison
+type sample struct {
+	number    int64
+	runs      int
+	successes int
+	durations []float64
+}
+
+func (s *sample) add(conclusion string, d float64, withDuration bool) {
+	s.runs++
+	if conclusion == ConclusionSuccess {
+		s.successes++
+	}
+	if withDuration {
+		s.durations = append(s.durations, d)
+	}
+}
+
+// WorkflowCompareParse summarizes both sides of a comparison and computes the deltas of the workflow, its jobs and their steps.
+// A change is significant when its p-value is below alpha. The success rates are compared with a two-proportion z-test
+// and the durations of successful executions with a Mann-Whitney U test.
+func WorkflowCompareParse(base, head ComparisonInput, alpha float64) *ComparisonSummary {
+	if alpha <= 0 {
+		alpha = DefaultSignificanceLevel
+	}
+	cs := &ComparisonSummary{
+		Alpha: alpha,
+		Base:  newComparisonSide(base),
+		Head:  newComparisonSide(head),
+		Diff:  []*ComparisonDiff{},
+	}
+
+	name := cs.Head.WorkflowRunsStatsSummary.Name
+	if name == "" {
+		name = cs.Base.WorkflowRunsStatsSummary.Name
+	}
+	cs.Diff = append(cs.Diff, newComparisonDiff(DeltaKindWorkflow, name, "", runsSample(base.Runs), runsSample(head.Runs), alpha))
+
+	baseJobs, baseSteps := jobsSamples(base.Jobs)
+	headJobs, headSteps := jobsSamples(head.Jobs)
+
+	jobNames := unionKeys(baseJobs, headJobs)
+	for _, job := range jobNames {
+		cs.Diff = append(cs.Diff, newComparisonDiff(DeltaKindJob, job, "", baseJobs[job], headJobs[job], alpha))
+
+		bs, hs := baseSteps[job], headSteps[job]
+		stepNames := unionKeys(bs, hs)
+		sort.SliceStable(stepNames, func(i, j int) bool {
+			return stepNumber(bs, hs, stepNames[i]) < stepNumber(bs, hs, stepNames[j])
+		})
+		for _, step := range stepNames {
+			cs.Diff = append(cs.Diff, newComparisonDiff(DeltaKindStep, step, job, bs[step], hs[step], alpha))
+		}
+	}
+
+	return cs
+}
+
+func newComparisonSide(in ComparisonInput) *ComparisonSide {
+	return &ComparisonSide{
+		Label:                    in.Label,
+		WorkflowRunsStatsSummary: WorkflowRunsParse(in.Runs),
+		WorkflowJobsStatsSummary: WorkflowJobsParse(in.Jobs),
+	}
+}
+
+func newComparisonDiff(kind, name, job string, base, head *sample, alpha float64) *ComparisonDiff {
+	if base == nil {
+		base = &sample{}
+	}
+	if head == nil {
+		head = &sample{}
+	}
+	d := &ComparisonDiff{
+		Kind:              kind,
+		Name:              name,
+		Job:               job,
+		Base:              newComparisonSample(base),
+		Head:              newComparisonSample(head),
+		SuccessRatePValue: twoProportionZTest(base.successes, base.runs, head.successes, head.runs),
+		DurationPValue:    mannWhitneyUTest(base.durations, head.durations),
+	}
+	d.SuccessRateDelta = d.Head.SuccesRate - d.Base.SuccesRate
+	d.SuccessRateSignificant = d.SuccessRatePValue < alpha
+	d.MedDelta = d.Head.Med - d.Base.Med
+	d.P95Delta = d.Head.P95 - d.Base.P95
+	d.DurationSignificant = d.DurationPValue < alpha
+	return d
+}
+
+func newComparisonSample(s *sample) ComparisonSample {
+	sorted := make([]float64, len(s.durations))
+	copy(sorted, s.durations)
+	sort.Float64s(sorted)
+	return ComparisonSample{
+		RunsCount:      s.runs,
+		SuccesRate:     adjustRate(float64(s.successes) / max(float64(s.runs), 1)),
+		Med:            calculatePercentile(sorted, 50),
+		P95:            calculatePercentile(sorted, 95),
+		DurationsCount: len(sorted),
+	}
+}
+
+// runsSample collects the same durations as WorkflowRunsParse
+func runsSample(wrs []*github.WorkflowRun) *sample {
+	s := &sample{}
+	for _, wr := range wrs {
+		c := wr.GetConclusion()
+		d := runDuration(wr)
+		s.add(c, d, c == ConclusionSuccess && d > 0 && wr.GetStatus() == StatusCompleted)
+	}
+	return s
+}
+
+// jobsSamples collects the same durations as WorkflowJobsParse. Steps are keyed by job name, then by step name.
+func jobsSamples(wjs []*github.WorkflowJob) (map[string]*sample, map[string]map[string]*sample) {
+	jobs := make(map[string]*sample)
+	steps := make(map[string]map[string]*sample)
+	for _, wj := range wjs {
+		if _, ok := jobs[wj.GetName()]; !ok {
+			jobs[wj.GetName()] = &sample{}
+			steps[wj.GetName()] = make(map[string]*sample)
+		}
+		c := wj.GetConclusion()
+		d := max(wj.GetCompletedAt().Sub(wj.GetStartedAt().Time).Seconds(), 0)
+		jobs[wj.GetName()].add(c, d, wj.GetStatus() == StatusCompleted && c == ConclusionSuccess)
+
+		for _, st := range wj.Steps {
+			if _, ok := steps[wj.GetName()][st.GetName()]; !ok {
+				steps[wj.GetName()][st.GetName()] = &sample{number: st.GetNumber()}
+			}
+			c := st.GetConclusion()
+			d := max(st.GetCompletedAt().Sub(st.GetStartedAt().Time).Seconds(), 0)
+			steps[wj.GetName()][st.GetName()].add(c, d, st.GetStatus() == StatusCompleted && (c == ConclusionSuccess || c == ConclusionFailure))
+		}
+	}
+	return jobs, steps
+}
+
+func unionKeys(a, b map[string]*sample) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func stepNumber(base, head map[string]*sample, name string) int64 {
+	if s, ok := head[name]; ok {
+		return s.number
+	}
+	return base[name].number
+}
@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newJob(name, conclusion string, started time.Time, d time.Duration, steps ...*github.TaskStep) *github.WorkflowJob {
+	return &github.WorkflowJob{
+		Name:        github.String(name),
+		Status:      github.String("completed"),
+		Conclusion:  github.String(conclusion),
+		StartedAt:   &github.Timestamp{Time: started},
+		CompletedAt: &github.Timestamp{Time: started.Add(d)},
+		Steps:       steps,
+	}
+}
+
+func newStep(name string, number int64, conclusion string, started time.Time, d time.Duration) *github.TaskStep {
+	return &github.TaskStep{
+		Name:        github.String(name),
+		Number:      github.Int64(number),
+		Status:      github.String("completed"),
+		Conclusion:  github.String(conclusion),
+		StartedAt:   &github.Timestamp{Time: started},
+		CompletedAt: &github.Timestamp{Time: started.Add(d)},
+	}
+}
+
+func TestWorkflowCompareParse(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	base := ComparisonInput{Label: "branch=main"}
+	head := ComparisonInput{Label: "branch=feature"}
+	for i := 0; i < 20; i++ {
+		base.Runs = append(base.Runs, newRun(int64(i), "success", start, time.Duration(10+i)*time.Second))
+		base.Jobs = append(base.Jobs, newJob("build", "success", start, time.Duration(10+i)*time.Second,
+			newStep("checkout", 1, "success", start, time.Second),
+			newStep("test", 2, "success", start, time.Duration(9+i)*time.Second),
+		))
+
+		conclusion := "success"
+		if i%2 == 0 {
+			conclusion = "failure"
+		}
+		head.Runs = append(head.Runs, newRun(int64(100+i), conclusion, start, time.Duration(30+i)*time.Second))
+		head.Jobs = append(head.Jobs, newJob("build", conclusion, start, time.Duration(30+i)*time.Second,
+			newStep("checkout", 1, "success", start, time.Second),
+			newStep("test", 2, conclusion, start, time.Duration(29+i)*time.Second),
+		))
+	}
+	head.Jobs = append(head.Jobs, newJob("lint", "success", start, 5*time.Second))
+
+	got := WorkflowCompareParse(base, head, 0)
+
+	assert.Equal(t, DefaultSignificanceLevel, got.Alpha)
+	assert.Equal(t, "branch=main", got.Base.Label)
+	assert.Equal(t, "branch=feature", got.Head.Label)
+	assert.Equal(t, 20, got.Base.WorkflowRunsStatsSummary.TotalRunsCount)
+	assert.Len(t, got.Head.WorkflowJobsStatsSummary, 2)
+
+	require.Len(t, got.Diff, 5)
+	kinds := []string{}
+	names := []string{}
+	for _, d := range got.Diff {
+		kinds = append(kinds, d.Kind)
+		names = append(names, d.Name)
+	}
+	assert.Equal(t, []string{"workflow", "job", "step", "step", "job"}, kinds)
+	assert.Equal(t, []string{"CI", "build", "checkout", "test", "lint"}, names)
+
+	wf := got.Diff[0]
+	assert.Equal(t, 1.0, wf.Base.SuccesRate)
+	assert.Equal(t, 0.5, wf.Head.SuccesRate)
+	assert.InDelta(t, -0.5, wf.SuccessRateDelta, eps)
+	assert.True(t, wf.SuccessRateSignificant)
+	assert.Equal(t, 19.5, wf.Base.Med)
+	assert.Equal(t, 10, wf.Head.DurationsCount)
+	assert.Equal(t, 40.0, wf.Head.Med)
+	assert.InDelta(t, 20.5, wf.MedDelta, eps)
+	assert.True(t, wf.DurationSignificant)
+
+	checkout := got.Diff[2]
+	assert.Equal(t, "build", checkout.Job)
+	assert.Equal(t, 0.0, checkout.SuccessRateDelta)
+	assert.Equal(t, 1.0, checkout.SuccessRatePValue)
+	assert.False(t, checkout.SuccessRateSignificant)
+	assert.False(t, checkout.DurationSignificant)
+
+	lint := got.Diff[4]
+	assert.Equal(t, 0, lint.Base.RunsCount)
+	assert.Equal(t, 1, lint.Head.RunsCount)
+	assert.Equal(t, 1.0, lint.SuccessRatePValue)
+	assert.Equal(t, 1.0, lint.DurationPValue)
+}
+
+func TestWorkflowCompareParse_Empty(t *testing.T) {
+	got := WorkflowCompareParse(ComparisonInput{}, ComparisonInput{}, 0.01)
+
+	assert.Equal(t, 0.01, got.Alpha)
+	require.Len(t, got.Diff, 1)
+	assert.Equal(t, DeltaKindWorkflow, got.Diff[0].Kind)
+	assert.False(t, got.Diff[0].SuccessRateSignificant)
+	assert.False(t, got.Diff[0].DurationSignificant)
+}
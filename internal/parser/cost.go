@@ -0,0 +1,209 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// Runners of the default price table. Jobs whose labels are not in the price table are billed as one of them.
+const (
+	RunnerLinux      = "linux"
+	RunnerWindows    = "windows"
+	RunnerMacOS      = "macos"
+	RunnerSelfHosted = "self-hosted"
+)
+
+// RunnerPrice is the price of a runner.
+// Billable minutes are the job minutes times Multiplier, the cost is the job minutes times PricePerMinute.
+type RunnerPrice struct {
+	Multiplier     float64 `json:"multiplier"`
+	PricePerMinute float64 `json:"price_per_minute"`
+}
+
+// PriceTable is keyed by runner label, e.g. ubuntu-latest-16-cores. Labels are case-insensitive.
+type PriceTable map[string]RunnerPrice
+
+// DefaultPriceTable returns the prices of the GitHub-hosted standard runners in USD, with GitHub's per-OS multipliers.
+// Self-hosted runners are free.
+func DefaultPriceTable() PriceTable {
+	return PriceTable{
+		RunnerLinux:      {Multiplier: 1, PricePerMinute: 0.008},
+		RunnerWindows:    {Multiplier: 2, PricePerMinute: 0.016},
+		RunnerMacOS:      {Multiplier: 10, PricePerMinute: 0.08},
+		RunnerSelfHosted: {Multiplier: 0, PricePerMinute: 0},
+	}
+}
+
+// ReadPriceTable reads a JSON price table and merges it into the default price table
+func ReadPriceTable(r io.Reader) (PriceTable, error) {
+	var pt PriceTable
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&pt); err != nil {
+		return nil, fmt.Errorf("failed to decode price table: %w", err)
+	}
+
+	res := DefaultPriceTable()
+	for label, p := range pt {
+		if p.Multiplier < 0 || p.PricePerMinute < 0 {
+			return nil, fmt.Errorf("negative price of runner %q", label)
+		}
+		res[strings.ToLower(label)] = p
+	}
+	return res, nil
+}
+
+// runner returns the price table label of the runner of a job.
+// A custom label of the price table wins, then self-hosted, whatever the order of the labels, e.g. runs-on: [linux, self-hosted].
+// Otherwise the runner is inferred from the labels and defaults to linux.
+func (pt PriceTable) runner(labels []string) string {
+	for _, l := range labels {
+		l = strings.ToLower(l)
+		if _, ok := pt[l]; ok && !isDefaultRunner(l) {
+			return l
+		}
+	}
+	for _, l := range labels {
+		if strings.EqualFold(l, RunnerSelfHosted) {
+			return RunnerSelfHosted
+		}
+	}
+	for _, l := range labels {
+		switch l := strings.ToLower(l); {
+		case strings.HasPrefix(l, RunnerWindows):
+			return RunnerWindows
+		case strings.HasPrefix(l, RunnerMacOS):
+			return RunnerMacOS
+		}
+	}
+	return RunnerLinux
+}
+
+// isDefaultRunner reports whether a label is a runner of the default price table
+func isDefaultRunner(label string) bool {
+	_, ok := DefaultPriceTable()[label]
+	return ok
+}
+
+// CostInput is the workflow runs and jobs of a workflow
+type CostInput struct {
+	Workflow string
+	Runs     []*github.WorkflowRun
+	Jobs     []*github.WorkflowJob
+}
+
+type CostSummary struct {
+	TotalRunsCount  int              `json:"total_runs_count"`
+	JobsCount       int              `json:"jobs_count"`
+	Minutes         float64          `json:"minutes"`
+	BillableMinutes float64          `json:"billable_minutes"`
+	Cost            float64          `json:"cost"`
+	CostPerRun      float64          `json:"cost_per_run"`
+	Workflows       []*CostBreakdown `json:"workflows"`
+	Jobs            []*CostBreakdown `json:"jobs"`
+	Runners         []*CostBreakdown `json:"runners"`
+	RateLimited     bool             `json:"rate_limited,omitempty"`
+}
+
+// CostBreakdown is the cost of a workflow, a job or a runner.
+// Minutes are the job minutes rounded up per job, as billed by GitHub.
+type CostBreakdown struct {
+	Name            string  `json:"name"`
+	Workflow        string  `json:"workflow,omitempty"`
+	JobsCount       int     `json:"jobs_count"`
+	Minutes         float64 `json:"minutes"`
+	BillableMinutes float64 `json:"billable_minutes"`
+	Cost            float64 `json:"cost"`
+}
+
+func (b *CostBreakdown) add(minutes float64, p RunnerPrice) {
+	b.JobsCount++
+	b.Minutes += minutes
+	b.BillableMinutes += minutes * p.Multiplier
+	b.Cost += minutes * p.PricePerMinute
+}
+
+// WorkflowCostParse estimates the billable minutes and the cost of the jobs of the workflows.
+// Every job is rounded up to the next whole minute. Jobs that did not run are free.
+func WorkflowCostParse(inputs []CostInput, pt PriceTable) *CostSummary {
+	cs := &CostSummary{
+		Workflows: []*CostBreakdown{},
+		Jobs:      []*CostBreakdown{},
+		Runners:   []*CostBreakdown{},
+	}
+	total := &CostBreakdown{}
+	jobs := make(map[[2]string]*CostBreakdown)
+	runners := make(map[string]*CostBreakdown)
+
+	for _, in := range inputs {
+		cs.TotalRunsCount += len(in.Runs)
+		wf := &CostBreakdown{Name: in.Workflow}
+		cs.Workflows = append(cs.Workflows, wf)
+
+		for _, wj := range in.Jobs {
+			if wj.GetStatus() != StatusCompleted || wj.StartedAt == nil || wj.CompletedAt == nil {
+				continue
+			}
+			d := wj.GetCompletedAt().Sub(wj.GetStartedAt().Time).Seconds()
+			if d <= 0 {
+				continue
+			}
+			minutes := math.Ceil(d / 60)
+			label := pt.runner(wj.Labels)
+			p := pt[label]
+
+			k := [2]string{in.Workflow, wj.GetName()}
+			if _, ok := jobs[k]; !ok {
+				jobs[k] = &CostBreakdown{Name: wj.GetName(), Workflow: in.Workflow}
+			}
+			if _, ok := runners[label]; !ok {
+				runners[label] = &CostBreakdown{Name: label}
+			}
+			wf.add(minutes, p)
+			jobs[k].add(minutes, p)
+			runners[label].add(minutes, p)
+			total.add(minutes, p)
+		}
+	}
+
+	for _, b := range jobs {
+		cs.Jobs = append(cs.Jobs, b)
+	}
+	for _, b := range runners {
+		cs.Runners = append(cs.Runners, b)
+	}
+	sortCostBreakdowns(cs.Workflows)
+	sortCostBreakdowns(cs.Jobs)
+	sortCostBreakdowns(cs.Runners)
+
+	cs.JobsCount = total.JobsCount
+	cs.Minutes = total.Minutes
+	cs.BillableMinutes = total.BillableMinutes
+	cs.Cost = total.Cost
+	if cs.TotalRunsCount > 0 {
+		cs.CostPerRun = cs.Cost / float64(cs.TotalRunsCount)
+	}
+	return cs
+}
+
+// sortCostBreakdowns sorts by cost, then billable minutes, in descending order. Ties are broken by name.
+func sortCostBreakdowns(bs []*CostBreakdown) {
+	sort.SliceStable(bs, func(i, j int) bool {
+		if bs[i].Cost != bs[j].Cost {
+			return bs[i].Cost > bs[j].Cost
+		}
+		if bs[i].BillableMinutes != bs[j].BillableMinutes {
+			return bs[i].BillableMinutes > bs[j].BillableMinutes
+		}
+		if bs[i].Workflow != bs[j].Workflow {
+			return bs[i].Workflow < bs[j].Workflow
+		}
+		return bs[i].Name < bs[j].Name
+	})
+}
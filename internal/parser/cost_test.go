@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPriceTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    PriceTable
+		wantErr bool
+	}{
+		{
+			name:  "Merged into the defaults",
+			input: `{"Ubuntu-Latest-16-Cores": {"multiplier": 1, "price_per_minute": 0.064}, "self-hosted": {"price_per_minute": 0.002}}`,
+			want: PriceTable{
+				RunnerLinux:              {Multiplier: 1, PricePerMinute: 0.008},
+				RunnerWindows:            {Multiplier: 2, PricePerMinute: 0.016},
+				RunnerMacOS:              {Multiplier: 10, PricePerMinute: 0.08},
+				RunnerSelfHosted:         {Multiplier: 0, PricePerMinute: 0.002},
+				"ubuntu-latest-16-cores": {Multiplier: 1, PricePerMinute: 0.064},
+			},
+		},
+		{
+			name:    "Unknown field",
+			input:   `{"linux": {"price": 0.1}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Negative price",
+			input:   `{"linux": {"price_per_minute": -1}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid JSON",
+			input:   `[`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadPriceTable(strings.NewReader(tt.input))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPriceTable_Runner(t *testing.T) {
+	pt := DefaultPriceTable()
+	pt["ubuntu-latest-16-cores"] = RunnerPrice{Multiplier: 1, PricePerMinute: 0.064}
+	pt["gpu"] = RunnerPrice{Multiplier: 1, PricePerMinute: 0.002}
+
+	tests := []struct {
+		labels []string
+		want   string
+	}{
+		{labels: nil, want: RunnerLinux},
+		{labels: []string{"ubuntu-latest"}, want: RunnerLinux},
+		{labels: []string{"windows-2022"}, want: RunnerWindows},
+		{labels: []string{"macos-14"}, want: RunnerMacOS},
+		{labels: []string{"self-hosted", "linux", "x64"}, want: RunnerSelfHosted},
+		{labels: []string{"linux", "self-hosted"}, want: RunnerSelfHosted},
+		{labels: []string{"Windows", "X64", "Self-Hosted"}, want: RunnerSelfHosted},
+		{labels: []string{"self-hosted", "arm64"}, want: RunnerSelfHosted},
+		{labels: []string{"self-hosted", "gpu"}, want: "gpu"},
+		{labels: []string{"linux"}, want: RunnerLinux},
+		{labels: []string{"windows"}, want: RunnerWindows},
+		{labels: []string{"Ubuntu-Latest-16-Cores"}, want: "ubuntu-latest-16-cores"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.labels, ","), func(t *testing.T) {
+			assert.Equal(t, tt.want, pt.runner(tt.labels))
+		})
+	}
+}
+
+func TestWorkflowCostParse(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := func(name string, d time.Duration, labels ...string) *github.WorkflowJob {
+		j := newJob(name, ConclusionSuccess, start, d)
+		j.Labels = labels
+		return j
+	}
+	skipped := job("lint", 0, "ubuntu-latest")
+	skipped.Conclusion = github.String("skipped")
+
+	inputs := []CostInput{
+		{
+			Workflow: "CI",
+			Runs:     []*github.WorkflowRun{{}, {}},
+			Jobs: []*github.WorkflowJob{
+				job("build", 61*time.Second, "ubuntu-latest"),
+				job("build", 30*time.Second, "ubuntu-latest"),
+				job("test", 90*time.Second, "windows-latest"),
+				skipped,
+			},
+		},
+		{
+			Workflow: "Release",
+			Runs:     []*github.WorkflowRun{{}},
+			Jobs: []*github.WorkflowJob{
+				job("build", 5*time.Minute, "macos-latest"),
+			},
+		},
+	}
+
+	cs := WorkflowCostParse(inputs, DefaultPriceTable())
+
+	assert.Equal(t, 3, cs.TotalRunsCount)
+	assert.Equal(t, 4, cs.JobsCount)
+	// 2 + 1 + 2 + 5 minutes
+	assert.Equal(t, 10.0, cs.Minutes)
+	// 3*1 + 2*2 + 5*10
+	assert.Equal(t, 57.0, cs.BillableMinutes)
+	assert.InDelta(t, 3*0.008+2*0.016+5*0.08, cs.Cost, 1e-9)
+	assert.InDelta(t, cs.Cost/3, cs.CostPerRun, 1e-9)
+
+	require.Len(t, cs.Workflows, 2)
+	assert.Equal(t, "Release", cs.Workflows[0].Name)
+	assert.Equal(t, "CI", cs.Workflows[1].Name)
+	assert.Equal(t, 3, cs.Workflows[1].JobsCount)
+
+	require.Len(t, cs.Jobs, 3)
+	assert.Equal(t, CostBreakdown{Name: "build", Workflow: "Release", JobsCount: 1, Minutes: 5, BillableMinutes: 50, Cost: 0.4}, *cs.Jobs[0])
+	assert.Equal(t, "test", cs.Jobs[1].Name)
+	assert.Equal(t, "build", cs.Jobs[2].Name)
+	assert.Equal(t, 3.0, cs.Jobs[2].Minutes)
+
+	require.Len(t, cs.Runners, 3)
+	assert.Equal(t, []string{RunnerMacOS, RunnerWindows, RunnerLinux}, []string{cs.Runners[0].Name, cs.Runners[1].Name, cs.Runners[2].Name})
+}
+
+func TestWorkflowCostParse_Empty(t *testing.T) {
+	cs := WorkflowCostParse(nil, DefaultPriceTable())
+	assert.Equal(t, &CostSummary{Workflows: []*CostBreakdown{}, Jobs: []*CostBreakdown{}, Runners: []*CostBreakdown{}}, cs)
+}
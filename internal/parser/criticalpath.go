@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// CriticalPathSummary aggregates the critical paths of the successful runs: how often every job gates the completion of a run and how much time it contributes.
+// The jobs API does not tell the dependencies of the jobs, the predecessor of a job on the critical path is the job that completed last before it started.
+type CriticalPathSummary struct {
+	RunsCount         int                    `json:"runs_count"`
+	PathDurationStats ExecutionDurationStats `json:"path_duration_stats"`
+	Jobs              []*CriticalPathJob     `json:"jobs"`
+	Runs              []*RunCriticalPath     `json:"runs"`
+}
+
+// CriticalPathJob is a job on the critical path of at least one run.
+// TimeStats are the execution times of the job when it is on the critical path, Share is its part of the total time of the critical paths.
+type CriticalPathJob struct {
+	Name              string                 `json:"name"`
+	RunsCount         int                    `json:"runs_count"`
+	CriticalRunsCount int                    `json:"critical_runs_count"`
+	CriticalRate      float64                `json:"critical_rate"`
+	TotalTime         float64                `json:"total_time"`
+	Share             float64                `json:"share"`
+	TimeStats         ExecutionDurationStats `json:"time_stats"`
+}
+
+// RunCriticalPath is the critical path of a run attempt, from its first job to its last job.
+// Duration is the time in seconds from the start of the first job to the completion of the last job of the path.
+type RunCriticalPath struct {
+	RunID      int64    `json:"run_id"`
+	RunAttempt int      `json:"run_attempt"`
+	HTMLURL    string   `json:"html_url"`
+	Jobs       []string `json:"jobs"`
+	Duration   float64  `json:"duration"`
+}
+
+// WorkflowCriticalPathParse computes the critical path of every successful run attempt and how often and how long every job sits on it.
+func WorkflowCriticalPathParse(wrs []*github.WorkflowRun, wjs []*github.WorkflowJob, opts ParseOptions) *CriticalPathSummary {
+	cs := &CriticalPathSummary{Jobs: []*CriticalPathJob{}, Runs: []*RunCriticalPath{}}
+
+	jobs := make(map[runAttemptKey][]*github.WorkflowJob)
+	for _, wj := range wjs {
+		if !timedJob(wj) {
+			continue
+		}
+		k := runAttemptKey{runID: wj.GetRunID(), attempt: wj.GetRunAttempt()}
+		jobs[k] = append(jobs[k], wj)
+	}
+
+	runs := make(map[string]int)
+	critical := make(map[string][]float64)
+	durations := []float64{}
+	for _, wr := range wrs {
+		if wr.GetConclusion() != ConclusionSuccess {
+			continue
+		}
+		js := jobs[runAttemptKey{runID: wr.GetID(), attempt: int64(wr.GetRunAttempt())}]
+		if len(js) == 0 {
+			continue
+		}
+		for _, name := range distinctJobNames(js) {
+			runs[name]++
+		}
+
+		path := criticalPath(js)
+		rp := &RunCriticalPath{
+			RunID:      wr.GetID(),
+			RunAttempt: wr.GetRunAttempt(),
+			HTMLURL:    wr.GetHTMLURL() + "/attempts/" + strconv.Itoa(wr.GetRunAttempt()),
+			Jobs:       make([]string, 0, len(path)),
+			Duration:   path[len(path)-1].GetCompletedAt().Sub(path[0].GetStartedAt().Time).Seconds(),
+		}
+		for _, wj := range path {
+			rp.Jobs = append(rp.Jobs, wj.GetName())
+			critical[wj.GetName()] = append(critical[wj.GetName()], jobExecutionTime(wj))
+		}
+		cs.Runs = append(cs.Runs, rp)
+		durations = append(durations, rp.Duration)
+	}
+	cs.RunsCount = len(cs.Runs)
+	cs.PathDurationStats = calcStats(durations, opts.Percentiles...)
+
+	total := 0.0
+	for name, ts := range critical {
+		j := &CriticalPathJob{
+			Name:              name,
+			RunsCount:         runs[name],
+			CriticalRunsCount: len(ts),
+			CriticalRate:      float64(len(ts)) / float64(runs[name]),
+			TimeStats:         calcStats(ts, opts.Percentiles...),
+		}
+		for _, t := range ts {
+			j.TotalTime += t
+		}
+		total += j.TotalTime
+		cs.Jobs = append(cs.Jobs, j)
+	}
+	for _, j := range cs.Jobs {
+		if total > 0 {
+			j.Share = j.TotalTime / total
+		}
+	}
+	sort.Slice(cs.Jobs, func(i, j int) bool {
+		if cs.Jobs[i].TotalTime != cs.Jobs[j].TotalTime {
+			return cs.Jobs[i].TotalTime > cs.Jobs[j].TotalTime
+		}
+		return cs.Jobs[i].Name < cs.Jobs[j].Name
+	})
+	sort.SliceStable(cs.Runs, func(i, j int) bool {
+		if cs.Runs[i].RunID != cs.Runs[j].RunID {
+			return cs.Runs[i].RunID > cs.Runs[j].RunID
+		}
+		return cs.Runs[i].RunAttempt > cs.Runs[j].RunAttempt
+	})
+	return cs
+}
+
+// criticalPath returns the chain of jobs that gated the completion of a run attempt, from its first job to its last job.
+// Starting from the job that completed last, the predecessor of a job is the job that completed last before it started.
+// The predecessor must complete before the job completes, so that the walk ends when zero-length jobs complete at the same second.
+func criticalPath(jobs []*github.WorkflowJob) []*github.WorkflowJob {
+	last := jobs[0]
+	for _, wj := range jobs[1:] {
+		if wj.GetCompletedAt().After(last.GetCompletedAt().Time) {
+			last = wj
+		}
+	}
+
+	path := []*github.WorkflowJob{last}
+	for cur := last; ; {
+		var prev *github.WorkflowJob
+		for _, wj := range jobs {
+			if wj == cur || wj.GetCompletedAt().After(cur.GetStartedAt().Time) || !wj.GetCompletedAt().Before(cur.GetCompletedAt().Time) {
+				continue
+			}
+			if prev == nil || wj.GetCompletedAt().After(prev.GetCompletedAt().Time) {
+				prev = wj
+			}
+		}
+		if prev == nil {
+			break
+		}
+		path = append(path, prev)
+		cur = prev
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// timedJob reports whether a job completed and ran, with a start and a completion time
+func timedJob(wj *github.WorkflowJob) bool {
+	if wj.GetStatus() != StatusCompleted || wj.GetConclusion() == "skipped" || wj.StartedAt == nil || wj.CompletedAt == nil {
+		return false
+	}
+	start, end := wj.GetStartedAt().Time, wj.GetCompletedAt().Time
+	return !start.IsZero() && !end.IsZero() && !end.Before(start)
+}
+
+// jobExecutionTime returns the execution time of a job in seconds
+func jobExecutionTime(wj *github.WorkflowJob) float64 {
+	return max(wj.GetCompletedAt().Sub(wj.GetStartedAt().Time).Seconds(), 0)
+}
+
+func distinctJobNames(jobs []*github.WorkflowJob) []string {
+	seen := make(map[string]bool, len(jobs))
+	names := make([]string, 0, len(jobs))
+	for _, wj := range jobs {
+		if !seen[wj.GetName()] {
+			seen[wj.GetName()] = true
+			names = append(names, wj.GetName())
+		}
+	}
+	return names
+}
@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowCriticalPathParse(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	minute := func(m int) time.Time { return start.Add(time.Duration(m) * time.Minute) }
+	job := func(runID int64, name string, from, to int) *github.WorkflowJob {
+		wj := newJob(name, ConclusionSuccess, minute(from), time.Duration(to-from)*time.Minute)
+		wj.RunID = github.Int64(runID)
+		wj.RunAttempt = github.Int64(1)
+		return wj
+	}
+	run := func(id int64, conclusion string) *github.WorkflowRun {
+		wr := newRun(id, conclusion, start, time.Hour)
+		wr.HTMLURL = github.String("https://example.com/runs/" + string(rune('0'+id)))
+		return wr
+	}
+
+	wrs := []*github.WorkflowRun{run(1, ConclusionSuccess), run(2, ConclusionSuccess), run(3, ConclusionFailure)}
+	wjs := []*github.WorkflowJob{
+		job(1, "lint", 0, 2), job(1, "build", 0, 5), job(1, "test", 5, 15), job(1, "e2e", 5, 8),
+		job(2, "lint", 0, 2), job(2, "build", 0, 3), job(2, "test", 3, 8), job(2, "e2e", 3, 12),
+		job(3, "build", 0, 30),
+	}
+
+	cs := WorkflowCriticalPathParse(wrs, wjs, ParseOptions{})
+
+	assert.Equal(t, 2, cs.RunsCount)
+	assert.Equal(t, 900.0, cs.PathDurationStats.Max)
+	assert.Equal(t, 720.0, cs.PathDurationStats.Min)
+	assert.Equal(t, []*RunCriticalPath{
+		{RunID: 2, RunAttempt: 1, HTMLURL: "https://example.com/runs/2/attempts/1", Jobs: []string{"build", "e2e"}, Duration: 720},
+		{RunID: 1, RunAttempt: 1, HTMLURL: "https://example.com/runs/1/attempts/1", Jobs: []string{"build", "test"}, Duration: 900},
+	}, cs.Runs)
+
+	require.Len(t, cs.Jobs, 3)
+	names := []string{cs.Jobs[0].Name, cs.Jobs[1].Name, cs.Jobs[2].Name}
+	assert.Equal(t, []string{"test", "e2e", "build"}, names)
+	test := cs.Jobs[0]
+	assert.Equal(t, 2, test.RunsCount)
+	assert.Equal(t, 1, test.CriticalRunsCount)
+	assert.Equal(t, 0.5, test.CriticalRate)
+	assert.Equal(t, 600.0, test.TotalTime)
+	assert.InDelta(t, 600.0/1620, test.Share, 1e-9)
+	build := cs.Jobs[2]
+	assert.Equal(t, 2, build.CriticalRunsCount)
+	assert.Equal(t, 1.0, build.CriticalRate)
+	assert.Equal(t, 240.0, build.TimeStats.Avg)
+}
+
+func TestCriticalPath_ZeroLengthJobs(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	jobs := []*github.WorkflowJob{
+		newJob("setup", ConclusionSuccess, start, 0),
+		newJob("lint", ConclusionSuccess, start, 0),
+		newJob("test", ConclusionSuccess, start, 5*time.Minute),
+	}
+
+	path := criticalPath(jobs)
+
+	names := make([]string, 0, len(path))
+	for _, wj := range path {
+		names = append(names, wj.GetName())
+	}
+	assert.Equal(t, []string{"setup", "test"}, names)
+
+	assert.Len(t, criticalPath(jobs[:2]), 1)
+}
+
+func TestWorkflowCriticalPathParse_Empty(t *testing.T) {
+	cs := WorkflowCriticalPathParse(nil, nil, ParseOptions{})
+
+	assert.Equal(t, 0, cs.RunsCount)
+	assert.Empty(t, cs.Jobs)
+	assert.Empty(t, cs.Runs)
+}
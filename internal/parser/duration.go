@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// Duration modes of the workflow runs
+const (
+	// DurationModeUpdated measures a run from its start to its last update. Any later edit of the run inflates it.
+	DurationModeUpdated = "updated"
+	// DurationModeJobs measures a run from the start of its first job to the completion of its last job
+	DurationModeJobs = "jobs"
+)
+
+// IsValidDurationMode reports whether mode is a supported duration mode
+func IsValidDurationMode(mode string) bool {
+	return mode == DurationModeUpdated || mode == DurationModeJobs
+}
+
+type runAttemptKey struct {
+	runID   int64
+	attempt int64
+}
+
+// jobTiming is the timing of the jobs of a run attempt
+type jobTiming struct {
+	// WallClock is the time from the start of the first job to the completion of the last job, in seconds
+	WallClock float64
+	// JobTime is the sum of the execution times of the jobs, in seconds
+	JobTime float64
+}
+
+// jobTimings returns the timing of the jobs of every run attempt. Jobs that did not run are ignored.
+func jobTimings(wjs []*github.WorkflowJob) map[runAttemptKey]jobTiming {
+	type span struct {
+		start, end time.Time
+		jobTime    float64
+	}
+	spans := make(map[runAttemptKey]*span)
+	for _, wj := range wjs {
+		if wj.GetStatus() != StatusCompleted || wj.StartedAt == nil || wj.CompletedAt == nil {
+			continue
+		}
+		start, end := wj.GetStartedAt().Time, wj.GetCompletedAt().Time
+		if start.IsZero() || end.IsZero() || end.Before(start) {
+			continue
+		}
+		k := runAttemptKey{runID: wj.GetRunID(), attempt: wj.GetRunAttempt()}
+		s, ok := spans[k]
+		if !ok {
+			s = &span{start: start, end: end}
+			spans[k] = s
+		}
+		if start.Before(s.start) {
+			s.start = start
+		}
+		if end.After(s.end) {
+			s.end = end
+		}
+		s.jobTime += end.Sub(start).Seconds()
+	}
+
+	res := make(map[runAttemptKey]jobTiming, len(spans))
+	for k, s := range spans {
+		res[k] = jobTiming{
+			WallClock: min(s.end.Sub(s.start).Seconds(), MaxWorkflowDurationCapped),
+			JobTime:   s.jobTime,
+		}
+	}
+	return res
+}
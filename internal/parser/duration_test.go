@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runJob returns a completed job of the run attempt
+func runJob(runID, attempt int64, started time.Time, d time.Duration) *github.WorkflowJob {
+	j := newJob("job", ConclusionSuccess, started, d)
+	j.RunID = github.Int64(runID)
+	j.RunAttempt = github.Int64(attempt)
+	return j
+}
+
+func TestIsValidDurationMode(t *testing.T) {
+	assert.True(t, IsValidDurationMode(DurationModeUpdated))
+	assert.True(t, IsValidDurationMode(DurationModeJobs))
+	assert.False(t, IsValidDurationMode(""))
+	assert.False(t, IsValidDurationMode("wall"))
+}
+
+func TestJobTimings(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	queued := runJob(1, 1, start, time.Minute)
+	queued.Status = github.String("queued")
+
+	timings := jobTimings([]*github.WorkflowJob{
+		// Run 1 attempt 1: two parallel jobs, then a dependent job
+		runJob(1, 1, start, 2*time.Minute),
+		runJob(1, 1, start.Add(30*time.Second), time.Minute),
+		runJob(1, 1, start.Add(2*time.Minute), time.Minute),
+		queued,
+		// Run 1 attempt 2
+		runJob(1, 2, start.Add(time.Hour), 10*time.Second),
+	})
+
+	require.Len(t, timings, 2)
+	assert.Equal(t, jobTiming{WallClock: 180, JobTime: 240}, timings[runAttemptKey{runID: 1, attempt: 1}])
+	assert.Equal(t, jobTiming{WallClock: 10, JobTime: 10}, timings[runAttemptKey{runID: 1, attempt: 2}])
+}
+
+func TestWorkflowRunsParseWithOptions_DurationModeJobs(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	run := func(id int64, attempt int) *github.WorkflowRun {
+		return &github.WorkflowRun{
+			ID:           github.Int64(id),
+			Status:       github.String(StatusCompleted),
+			Conclusion:   github.String(ConclusionSuccess),
+			RunAttempt:   github.Int(attempt),
+			RunStartedAt: &github.Timestamp{Time: start},
+			// Edited a day later
+			UpdatedAt: &github.Timestamp{Time: start.Add(24 * time.Hour)},
+		}
+	}
+	wrs := []*github.WorkflowRun{run(1, 1), run(2, 1), run(3, 1)}
+	jobs := []*github.WorkflowJob{
+		runJob(1, 1, start, 2*time.Minute),
+		runJob(1, 1, start, time.Minute),
+		runJob(2, 1, start, 4*time.Minute),
+		// Run 3 has no timed job
+	}
+
+	updated := WorkflowRunsParseWithOptions(wrs, ParseOptions{Jobs: jobs})
+	assert.Equal(t, 86400.0, updated.ExecutionDurationStats.Med)
+	assert.Nil(t, updated.JobTimeStats)
+
+	res := WorkflowRunsParseWithOptions(wrs, ParseOptions{DurationMode: DurationModeJobs, Jobs: jobs})
+	assert.Equal(t, ExecutionDurationStats{Min: 120, Max: 240, Avg: 180, Med: 180, Std: 60}, res.ExecutionDurationStats)
+	require.NotNil(t, res.JobTimeStats)
+	assert.Equal(t, 180.0, res.JobTimeStats.Min)
+	assert.Equal(t, 240.0, res.JobTimeStats.Max)
+
+	runs := res.Conclusions[ConclusionSuccess].WorkflowRuns
+	require.Len(t, runs, 3)
+	assert.Equal(t, 120.0, runs[0].Duration)
+	assert.Equal(t, 180.0, runs[0].JobTime)
+	assert.Equal(t, 0.0, runs[2].Duration)
+}
@@ -0,0 +1,193 @@
+package parser
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/types"
+	"github.com/google/go-github/v60/github"
+)
+
+const (
+	// failureExcerptLines is the number of log lines kept before and after an error line
+	failureExcerptLines = 3
+	// maxFailureMessageLength truncates the normalized error messages
+	maxFailureMessageLength = 200
+)
+
+var (
+	logTimestampRe = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z ?`)
+	ansiRe         = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	genericErrorRe = regexp.MustCompile(`^Process completed with exit code \d+\.?$`)
+	uuidRe         = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	hexRe          = regexp.MustCompile(`(?i)\b[0-9a-f]{7,}\b`)
+	numberRe       = regexp.MustCompile(`\d+(\.\d+)*`)
+	spacesRe       = regexp.MustCompile(`\s+`)
+)
+
+// FailureLogsSummary clusters the failed jobs by the normalized error message of their logs
+type FailureLogsSummary struct {
+	FailuresCount int               `json:"failures_count"`
+	LogsCount     int               `json:"logs_count"`
+	Clusters      []*FailureCluster `json:"clusters"`
+}
+
+// FailureCluster is a set of failed jobs with the same normalized error message.
+// Excerpt is the log lines around the error of the first failure of the cluster.
+type FailureCluster struct {
+	Message  string   `json:"message"`
+	Count    int      `json:"count"`
+	Jobs     []string `json:"jobs"`
+	Steps    []string `json:"steps"`
+	Excerpt  []string `json:"excerpt"`
+	HTMLURLs []string `json:"html_urls"`
+}
+
+// WorkflowFailureLogsParse extracts the error of the logs of the failed jobs and clusters the failures by error message.
+// logs are keyed by job ID, failed jobs without a log or an error line are counted in FailuresCount only.
+func WorkflowFailureLogsParse(wjs []*github.WorkflowJob, logs map[int64]string) *FailureLogsSummary {
+	fs := &FailureLogsSummary{Clusters: []*FailureCluster{}}
+	clusters := make(map[string]*FailureCluster)
+	for _, wj := range sortJobsByCompletion(wjs) {
+		if wj.GetConclusion() != ConclusionFailure {
+			continue
+		}
+		fs.FailuresCount++
+		log, ok := logs[wj.GetID()]
+		if !ok {
+			continue
+		}
+		fs.LogsCount++
+		message, excerpt, ok := errorExcerpt(log)
+		if !ok {
+			continue
+		}
+
+		key := normalizeFailureMessage(message)
+		c, ok := clusters[key]
+		if !ok {
+			c = &FailureCluster{Message: key, Jobs: []string{}, Steps: []string{}, Excerpt: excerpt, HTMLURLs: []string{}}
+			clusters[key] = c
+			fs.Clusters = append(fs.Clusters, c)
+		}
+		c.Count++
+		c.HTMLURLs = append(c.HTMLURLs, wj.GetHTMLURL())
+		if !slices.Contains(c.Jobs, wj.GetName()) {
+			c.Jobs = append(c.Jobs, wj.GetName())
+		}
+		if s := failedStep(wj); s != "" && !slices.Contains(c.Steps, s) {
+			c.Steps = append(c.Steps, s)
+		}
+	}
+
+	for _, c := range fs.Clusters {
+		sort.Strings(c.Jobs)
+		sort.Strings(c.Steps)
+	}
+	sort.SliceStable(fs.Clusters, func(i, j int) bool {
+		if fs.Clusters[i].Count != fs.Clusters[j].Count {
+			return fs.Clusters[i].Count > fs.Clusters[j].Count
+		}
+		return fs.Clusters[i].Message < fs.Clusters[j].Message
+	})
+	return fs
+}
+
+// sortJobsByCompletion returns a copy of the jobs sorted by completion time and ID.
+// Jobs are fetched concurrently, so sorting makes the first failure of a cluster, and its excerpt, the earliest one.
+func sortJobsByCompletion(wjs []*github.WorkflowJob) []*github.WorkflowJob {
+	sorted := make([]*github.WorkflowJob, len(wjs))
+	copy(sorted, wjs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].GetCompletedAt().Time, sorted[j].GetCompletedAt().Time
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return sorted[i].GetID() < sorted[j].GetID()
+	})
+	return sorted
+}
+
+// errorExcerpt returns the first error message of a job log and the lines around it.
+// "Process completed with exit code N" only tells that a command failed, the last output of the command is reported instead.
+func errorExcerpt(log string) (string, []string, bool) {
+	lines := strings.Split(log, "\n")
+	for i, l := range lines {
+		l = strings.TrimRight(l, "\r")
+		l = logTimestampRe.ReplaceAllString(l, "")
+		lines[i] = ansiRe.ReplaceAllString(l, "")
+	}
+
+	at := -1
+	for i, l := range lines {
+		idx := strings.Index(l, types.LogErrorMarker)
+		if idx < 0 {
+			continue
+		}
+		if at < 0 {
+			at = i
+		}
+		if !genericErrorRe.MatchString(strings.TrimSpace(l[idx+len(types.LogErrorMarker):])) {
+			at = i
+			break
+		}
+	}
+	if at < 0 {
+		return "", nil, false
+	}
+
+	line := lines[at]
+	message := strings.TrimSpace(line[strings.Index(line, types.LogErrorMarker)+len(types.LogErrorMarker):])
+	if genericErrorRe.MatchString(message) {
+		for i := at - 1; i >= 0; i-- {
+			if l := strings.TrimSpace(lines[i]); l != "" && !strings.HasPrefix(l, "##[") {
+				message = l
+				break
+			}
+		}
+	}
+
+	excerpt := make([]string, 0, 2*failureExcerptLines+1)
+	for _, l := range lines[max(at-failureExcerptLines, 0):min(at+failureExcerptLines+1, len(lines))] {
+		if strings.TrimSpace(l) != "" {
+			excerpt = append(excerpt, l)
+		}
+	}
+	return message, excerpt, true
+}
+
+// normalizeFailureMessage replaces the IDs, hashes and numbers of an error message so that the same error of different runs matches
+func normalizeFailureMessage(message string) string {
+	message = uuidRe.ReplaceAllString(message, "<uuid>")
+	message = hexRe.ReplaceAllStringFunc(message, func(h string) string {
+		// Words made of the letters a to f only are not hashes
+		if strings.ContainsAny(h, "0123456789") {
+			return "<hex>"
+		}
+		return h
+	})
+	message = numberRe.ReplaceAllString(message, "N")
+	message = strings.TrimSpace(spacesRe.ReplaceAllString(message, " "))
+	if len(message) > maxFailureMessageLength {
+		// Cut on a rune boundary so that the message stays valid UTF-8
+		n := maxFailureMessageLength
+		for n > 0 && !utf8.RuneStart(message[n]) {
+			n--
+		}
+		message = message[:n]
+	}
+	return message
+}
+
+// failedStep returns the name of the first failed step of a job
+func failedStep(wj *github.WorkflowJob) string {
+	for _, s := range wj.Steps {
+		if s.GetConclusion() == ConclusionFailure {
+			return s.GetName()
+		}
+	}
+	return ""
+}
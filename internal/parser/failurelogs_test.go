@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowFailureLogsParse(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := func(id int64, name, conclusion string) *github.WorkflowJob {
+		j := newJob(name, conclusion, start, time.Minute,
+			newStep("checkout", 1, ConclusionSuccess, start, time.Second),
+			newStep("push image", 2, conclusion, start, time.Second),
+		)
+		j.ID = github.Int64(id)
+		j.HTMLURL = github.String("https://github.com/owner/repo/actions/runs/1/job/" + name)
+		return j
+	}
+	registryLog := func(port string) string {
+		return "2024-01-01T00:00:00.0000000Z ##[group]Run docker push\n" +
+			"2024-01-01T00:00:01.0000000Z pushing layer 3f2a1b9c8d\n" +
+			"2024-01-01T00:00:02.0000000Z read tcp 10.0.0.1:" + port + ": connection reset by peer\n" +
+			"2024-01-01T00:00:03.0000000Z ##[error]Process completed with exit code 1.\n"
+	}
+
+	wjs := []*github.WorkflowJob{
+		job(1, "build", ConclusionFailure),
+		job(2, "release", ConclusionFailure),
+		job(3, "test", ConclusionFailure),
+		job(4, "lint", ConclusionFailure),
+		job(5, "build", ConclusionSuccess),
+	}
+	logs := map[int64]string{
+		1: registryLog("51234"),
+		2: registryLog("40022"),
+		3: "##[error]Tests failed: 3 of 120\n##[error]Process completed with exit code 1.\n",
+		4: "no error marker\n",
+	}
+
+	fs := WorkflowFailureLogsParse(wjs, logs)
+
+	assert.Equal(t, 4, fs.FailuresCount)
+	assert.Equal(t, 4, fs.LogsCount)
+	require.Len(t, fs.Clusters, 2)
+	assert.Equal(t, &FailureCluster{
+		Message: "read tcp N:N: connection reset by peer",
+		Count:   2,
+		Jobs:    []string{"build", "release"},
+		Steps:   []string{"push image"},
+		Excerpt: []string{
+			"##[group]Run docker push",
+			"pushing layer 3f2a1b9c8d",
+			"read tcp 10.0.0.1:51234: connection reset by peer",
+			"##[error]Process completed with exit code 1.",
+		},
+		HTMLURLs: []string{
+			"https://github.com/owner/repo/actions/runs/1/job/build",
+			"https://github.com/owner/repo/actions/runs/1/job/release",
+		},
+	}, fs.Clusters[0])
+	assert.Equal(t, "Tests failed: N of N", fs.Clusters[1].Message)
+	assert.Equal(t, 1, fs.Clusters[1].Count)
+}
+
+func TestWorkflowFailureLogsParse_EarliestExcerpt(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := func(id int64, started time.Time) *github.WorkflowJob {
+		j := newJob("test", ConclusionFailure, started, time.Minute)
+		j.ID = github.Int64(id)
+		return j
+	}
+	wjs := []*github.WorkflowJob{
+		job(3, start.Add(time.Hour)),
+		job(2, start),
+		job(1, start),
+	}
+	logs := map[int64]string{
+		1: "run 1\n##[error]Tests failed\n",
+		2: "run 2\n##[error]Tests failed\n",
+		3: "run 3\n##[error]Tests failed\n",
+	}
+
+	fs := WorkflowFailureLogsParse(wjs, logs)
+
+	require.Len(t, fs.Clusters, 1)
+	assert.Equal(t, []string{"run 1", "##[error]Tests failed"}, fs.Clusters[0].Excerpt)
+}
+
+func TestNormalizeFailureMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{message: "connection reset", want: "connection reset"},
+		{message: "commit 3f2a1b9c8d4e not found", want: "commit <hex> not found"},
+		{message: "request 123e4567-e89b-12d3-a456-426614174000   timed out after 30s", want: "request <uuid> timed out after Ns"},
+		{message: "image acceded", want: "image acceded"},
+		// "é" is 2 bytes: byte 200 is the second byte of the 100th rune
+		{message: "x" + strings.Repeat("é", 150), want: "x" + strings.Repeat("é", 99)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			got := normalizeFailureMessage(tt.message)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, utf8.ValidString(got))
+		})
+	}
+}
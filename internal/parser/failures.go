@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// AnnotationLevelFailure is the level of the check run annotations of errors
+const AnnotationLevelFailure = "failure"
+
+// FailureSignaturesSummary groups the failed jobs by failure signature.
+// Many failures of a few signatures are one breakage, many signatures are unrelated failures.
+type FailureSignaturesSummary struct {
+	FailuresCount int                 `json:"failures_count"`
+	Signatures    []*FailureSignature `json:"signatures"`
+}
+
+// FailureSignature is a set of failed jobs with the same job name, failed step and normalized annotation message
+type FailureSignature struct {
+	Signature string    `json:"signature"`
+	Job       string    `json:"job"`
+	Step      string    `json:"step"`
+	Message   string    `json:"message"`
+	Count     int       `json:"count"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	HTMLURLs  []string  `json:"html_urls"`
+}
+
+// WorkflowFailureSignaturesParse groups the failed jobs by job name, failed step and failure annotation message.
+// annotations are keyed by job ID. The HTML URLs of a signature are sorted from the latest failure.
+func WorkflowFailureSignaturesParse(wjs []*github.WorkflowJob, annotations map[int64][]*github.CheckRunAnnotation) *FailureSignaturesSummary {
+	fs := &FailureSignaturesSummary{Signatures: []*FailureSignature{}}
+	signatures := make(map[signatureKey]*FailureSignature)
+	seen := make(map[*FailureSignature][]*github.WorkflowJob)
+	for _, wj := range wjs {
+		if wj.GetConclusion() != ConclusionFailure {
+			continue
+		}
+		fs.FailuresCount++
+
+		job, step, message := wj.GetName(), failedStep(wj), normalizeFailureMessage(annotationMessage(annotations[wj.GetID()]))
+		key := signatureKey{job: job, step: step, message: message}
+		s, ok := signatures[key]
+		if !ok {
+			s = &FailureSignature{Signature: key.String(), Job: job, Step: step, Message: message}
+			signatures[key] = s
+			fs.Signatures = append(fs.Signatures, s)
+		}
+		s.Count++
+		seen[s] = append(seen[s], wj)
+	}
+
+	for _, s := range fs.Signatures {
+		jobs := seen[s]
+		sort.SliceStable(jobs, func(i, j int) bool {
+			return failedAt(jobs[i]).After(failedAt(jobs[j]))
+		})
+		s.LastSeen = failedAt(jobs[0])
+		s.FirstSeen = failedAt(jobs[len(jobs)-1])
+		s.HTMLURLs = make([]string, 0, len(jobs))
+		for _, wj := range jobs {
+			s.HTMLURLs = append(s.HTMLURLs, wj.GetHTMLURL())
+		}
+	}
+	sort.SliceStable(fs.Signatures, func(i, j int) bool {
+		if fs.Signatures[i].Count != fs.Signatures[j].Count {
+			return fs.Signatures[i].Count > fs.Signatures[j].Count
+		}
+		return fs.Signatures[i].LastSeen.After(fs.Signatures[j].LastSeen)
+	})
+	return fs
+}
+
+// annotationMessage returns the message of the first failure annotation.
+// "Process completed with exit code N" is only used when there is no other failure annotation.
+func annotationMessage(annotations []*github.CheckRunAnnotation) string {
+	message := ""
+	for _, a := range annotations {
+		if a.GetAnnotationLevel() != AnnotationLevelFailure {
+			continue
+		}
+		m := strings.TrimSpace(a.GetMessage())
+		if !genericErrorRe.MatchString(m) {
+			return m
+		}
+		if message == "" {
+			message = m
+		}
+	}
+	return message
+}
+
+// signatureKey identifies a failure signature. An empty part, e.g. no failed step, is part of the key.
+type signatureKey struct {
+	job, step, message string
+}
+
+// String formats a failure signature for display. A missing failed step is spelled out when a message follows it, so that signatures read differently.
+func (k signatureKey) String() string {
+	parts := []string{k.job}
+	switch {
+	case k.step != "":
+		parts = append(parts, k.step)
+	case k.message != "":
+		parts = append(parts, "no failed step")
+	}
+	if k.message != "" {
+		parts = append(parts, k.message)
+	}
+	return strings.Join(parts, " / ")
+}
+
+// failedAt returns the completion time of a job, or its start time when it did not complete
+func failedAt(wj *github.WorkflowJob) time.Time {
+	if t := wj.GetCompletedAt().UTC(); !t.IsZero() {
+		return t
+	}
+	return wj.GetStartedAt().UTC()
+}
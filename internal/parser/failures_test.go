@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowFailureSignaturesParse(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	job := func(id int64, name, conclusion string, started time.Time) *github.WorkflowJob {
+		j := newJob(name, conclusion, started, time.Minute, newStep("run tests", 1, conclusion, started, time.Second))
+		j.ID = github.Int64(id)
+		j.HTMLURL = github.String("https://example.com/" + string(rune('0'+id)))
+		return j
+	}
+	annotation := func(level, message string) *github.CheckRunAnnotation {
+		return &github.CheckRunAnnotation{AnnotationLevel: github.String(level), Message: github.String(message)}
+	}
+
+	wjs := []*github.WorkflowJob{
+		job(1, "test", ConclusionFailure, day(3)),
+		job(2, "test", ConclusionFailure, day(1)),
+		job(3, "test", ConclusionFailure, day(2)),
+		job(4, "test", ConclusionSuccess, day(2)),
+		job(5, "lint", ConclusionFailure, day(4)),
+	}
+	annotations := map[int64][]*github.CheckRunAnnotation{
+		1: {annotation("failure", "Process completed with exit code 1."), annotation("failure", "TestFoo timed out after 30s")},
+		2: {annotation("warning", "Node.js 16 actions are deprecated"), annotation("failure", "TestFoo timed out after 10s")},
+		3: {annotation("failure", "Process completed with exit code 2.")},
+	}
+
+	fs := WorkflowFailureSignaturesParse(wjs, annotations)
+
+	assert.Equal(t, 4, fs.FailuresCount)
+	require.Len(t, fs.Signatures, 3)
+	assert.Equal(t, &FailureSignature{
+		Signature: "test / run tests / TestFoo timed out after Ns",
+		Job:       "test",
+		Step:      "run tests",
+		Message:   "TestFoo timed out after Ns",
+		Count:     2,
+		FirstSeen: day(1).Add(time.Minute),
+		LastSeen:  day(3).Add(time.Minute),
+		HTMLURLs:  []string{"https://example.com/1", "https://example.com/2"},
+	}, fs.Signatures[0])
+	assert.Equal(t, "lint / run tests", fs.Signatures[1].Signature)
+	assert.Equal(t, "test / run tests / Process completed with exit code N.", fs.Signatures[2].Signature)
+}
+
+func TestWorkflowFailureSignaturesParse_Empty(t *testing.T) {
+	assert.Equal(t, &FailureSignaturesSummary{Signatures: []*FailureSignature{}}, WorkflowFailureSignaturesParse(nil, nil))
+}
+
+func TestWorkflowFailureSignaturesParse_EmptyParts(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	noStep := newJob("build", ConclusionFailure, start, time.Minute)
+	noStep.ID = github.Int64(1)
+	noMessage := newJob("build", ConclusionFailure, start, time.Minute, newStep("test", 1, ConclusionFailure, start, time.Second))
+	noMessage.ID = github.Int64(2)
+	annotations := map[int64][]*github.CheckRunAnnotation{
+		1: {{AnnotationLevel: github.String("failure"), Message: github.String("test")}},
+	}
+
+	fs := WorkflowFailureSignaturesParse([]*github.WorkflowJob{noStep, noMessage}, annotations)
+
+	require.Len(t, fs.Signatures, 2)
+	assert.ElementsMatch(t, []string{"build / no failed step / test", "build / test"}, []string{fs.Signatures[0].Signature, fs.Signatures[1].Signature})
+}
+
+func TestSignatureKeyString(t *testing.T) {
+	tests := []struct {
+		key  signatureKey
+		want string
+	}{
+		{key: signatureKey{job: "build", step: "test", message: "boom"}, want: "build / test / boom"},
+		{key: signatureKey{job: "build", step: "test"}, want: "build / test"},
+		{key: signatureKey{job: "build", message: "boom"}, want: "build / no failed step / boom"},
+		{key: signatureKey{job: "build"}, want: "build"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.key.String())
+	}
+}
@@ -0,0 +1,212 @@
+package parser
+
+import (
+	"sort"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// FlakySummary reports the jobs and steps that failed on an attempt of a run and succeeded on a later attempt of the same run.
+// All attempts of a run build the same commit, so such a failure is a flake.
+type FlakySummary struct {
+	RunsCount        int         `json:"runs_count"`
+	RetriedRunsCount int         `json:"retried_runs_count"`
+	FlakyRunsCount   int         `json:"flaky_runs_count"`
+	Jobs             []*FlakyJob `json:"jobs"`
+}
+
+// FlakyJob is a job that flaked on at least one run
+type FlakyJob struct {
+	Name           string          `json:"name"`
+	RunsCount      int             `json:"runs_count"`
+	FlakyRunsCount int             `json:"flaky_runs_count"`
+	FlakeRate      float64         `json:"flake_rate"`
+	Steps          []*FlakyStep    `json:"steps"`
+	Attempts       []*FlakyAttempt `json:"attempts"`
+}
+
+// FlakyStep is a step of a job that flaked on at least one run
+type FlakyStep struct {
+	Name           string  `json:"name"`
+	Number         int64   `json:"number"`
+	RunsCount      int     `json:"runs_count"`
+	FlakyRunsCount int     `json:"flaky_runs_count"`
+	FlakeRate      float64 `json:"flake_rate"`
+}
+
+// FlakyAttempt is a failed attempt of a job that succeeded on a later attempt
+type FlakyAttempt struct {
+	RunID      int64  `json:"run_id"`
+	RunAttempt int64  `json:"run_attempt"`
+	HeadSHA    string `json:"head_sha"`
+	HTMLURL    string `json:"html_url"`
+}
+
+type flakyStepCalc struct {
+	number    int64
+	runs      int
+	flakyRuns int
+}
+
+type flakyJobCalc struct {
+	runs      int
+	flakyRuns int
+	attempts  []*FlakyAttempt
+	steps     map[string]*flakyStepCalc
+}
+
+// WorkflowFlakyParse groups the jobs of every attempt by run and job name and detects flaky jobs and steps.
+// Jobs and steps that did not flake are not reported.
+func WorkflowFlakyParse(wjs []*github.WorkflowJob) *FlakySummary {
+	// Jobs of every run, by job name
+	runs := make(map[int64]map[string][]*github.WorkflowJob)
+	for _, wj := range wjs {
+		if wj.GetStatus() != StatusCompleted {
+			continue
+		}
+		if _, ok := runs[wj.GetRunID()]; !ok {
+			runs[wj.GetRunID()] = make(map[string][]*github.WorkflowJob)
+		}
+		runs[wj.GetRunID()][wj.GetName()] = append(runs[wj.GetRunID()][wj.GetName()], wj)
+	}
+
+	fs := &FlakySummary{RunsCount: len(runs), Jobs: []*FlakyJob{}}
+	calcs := make(map[string]*flakyJobCalc)
+	for _, jobs := range runs {
+		retried, flaky := false, false
+		for name, attempts := range jobs {
+			sort.SliceStable(attempts, func(i, j int) bool {
+				return attempts[i].GetRunAttempt() < attempts[j].GetRunAttempt()
+			})
+			if attempts[len(attempts)-1].GetRunAttempt() > attempts[0].GetRunAttempt() {
+				retried = true
+			}
+
+			c, ok := calcs[name]
+			if !ok {
+				c = &flakyJobCalc{steps: make(map[string]*flakyStepCalc)}
+				calcs[name] = c
+			}
+			c.runs++
+			if failed := flakyAttempts(attempts); len(failed) > 0 {
+				flaky = true
+				c.flakyRuns++
+				for _, wj := range failed {
+					c.attempts = append(c.attempts, &FlakyAttempt{
+						RunID:      wj.GetRunID(),
+						RunAttempt: wj.GetRunAttempt(),
+						HeadSHA:    wj.GetHeadSHA(),
+						HTMLURL:    wj.GetHTMLURL(),
+					})
+				}
+			}
+			addFlakySteps(c, attempts)
+		}
+		if retried {
+			fs.RetriedRunsCount++
+		}
+		if flaky {
+			fs.FlakyRunsCount++
+		}
+	}
+
+	for name, c := range calcs {
+		if c.flakyRuns == 0 {
+			continue
+		}
+		fj := &FlakyJob{
+			Name:           name,
+			RunsCount:      c.runs,
+			FlakyRunsCount: c.flakyRuns,
+			FlakeRate:      float64(c.flakyRuns) / float64(c.runs),
+			Steps:          []*FlakyStep{},
+			Attempts:       c.attempts,
+		}
+		for sn, s := range c.steps {
+			if s.flakyRuns == 0 {
+				continue
+			}
+			fj.Steps = append(fj.Steps, &FlakyStep{
+				Name:           sn,
+				Number:         s.number,
+				RunsCount:      s.runs,
+				FlakyRunsCount: s.flakyRuns,
+				FlakeRate:      float64(s.flakyRuns) / float64(s.runs),
+			})
+		}
+		sort.SliceStable(fj.Steps, func(i, j int) bool {
+			return fj.Steps[i].Number < fj.Steps[j].Number
+		})
+		sort.SliceStable(fj.Attempts, func(i, j int) bool {
+			if fj.Attempts[i].RunID != fj.Attempts[j].RunID {
+				return fj.Attempts[i].RunID > fj.Attempts[j].RunID
+			}
+			return fj.Attempts[i].RunAttempt < fj.Attempts[j].RunAttempt
+		})
+		fs.Jobs = append(fs.Jobs, fj)
+	}
+	sort.SliceStable(fs.Jobs, func(i, j int) bool {
+		if fs.Jobs[i].FlakyRunsCount != fs.Jobs[j].FlakyRunsCount {
+			return fs.Jobs[i].FlakyRunsCount > fs.Jobs[j].FlakyRunsCount
+		}
+		return fs.Jobs[i].Name < fs.Jobs[j].Name
+	})
+	return fs
+}
+
+// flakyAttempts returns the failed attempts of a job that succeeded on a later attempt. attempts are sorted by attempt.
+func flakyAttempts(attempts []*github.WorkflowJob) []*github.WorkflowJob {
+	lastSuccess := -1
+	for i, wj := range attempts {
+		if wj.GetConclusion() == ConclusionSuccess {
+			lastSuccess = i
+		}
+	}
+	failed := []*github.WorkflowJob{}
+	for _, wj := range attempts[:max(lastSuccess, 0)] {
+		if wj.GetConclusion() == ConclusionFailure {
+			failed = append(failed, wj)
+		}
+	}
+	return failed
+}
+
+// addFlakySteps counts the runs of the steps of a job and the runs where a step failed and succeeded on a later attempt
+func addFlakySteps(c *flakyJobCalc, attempts []*github.WorkflowJob) {
+	// Conclusions of every step by attempt
+	conclusions := make(map[string]map[int64]string)
+	for _, wj := range attempts {
+		for _, s := range wj.Steps {
+			if _, ok := c.steps[s.GetName()]; !ok {
+				c.steps[s.GetName()] = &flakyStepCalc{number: s.GetNumber()}
+			}
+			if _, ok := conclusions[s.GetName()]; !ok {
+				conclusions[s.GetName()] = make(map[int64]string)
+			}
+			conclusions[s.GetName()][wj.GetRunAttempt()] = s.GetConclusion()
+		}
+	}
+
+	for name, byAttempt := range conclusions {
+		sc := c.steps[name]
+		sc.runs++
+		if isFlaky(byAttempt) {
+			sc.flakyRuns++
+		}
+	}
+}
+
+// isFlaky reports whether a failed attempt is followed by a successful attempt
+func isFlaky(byAttempt map[int64]string) bool {
+	for a, conclusion := range byAttempt {
+		if conclusion != ConclusionFailure {
+			continue
+		}
+		for b, later := range byAttempt {
+			if b > a && later == ConclusionSuccess {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowFlakyParse(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := func(runID, attempt int64, name, conclusion string, steps ...*github.TaskStep) *github.WorkflowJob {
+		j := newJob(name, conclusion, start, time.Minute, steps...)
+		j.RunID = github.Int64(runID)
+		j.RunAttempt = github.Int64(attempt)
+		j.HeadSHA = github.String("sha")
+		j.HTMLURL = github.String("https://github.com/owner/repo/actions/runs/" + name)
+		return j
+	}
+	step := func(name string, number int64, conclusion string) *github.TaskStep {
+		return newStep(name, number, conclusion, start, time.Second)
+	}
+
+	wjs := []*github.WorkflowJob{
+		// Run 1: test flaked on attempt 1 at the "run tests" step
+		job(1, 2, "test", ConclusionSuccess, step("checkout", 1, ConclusionSuccess), step("run tests", 2, ConclusionSuccess)),
+		job(1, 1, "test", ConclusionFailure, step("checkout", 1, ConclusionSuccess), step("run tests", 2, ConclusionFailure)),
+		job(1, 1, "build", ConclusionSuccess),
+		job(1, 2, "build", ConclusionSuccess),
+		// Run 2: test failed twice, a real failure
+		job(2, 1, "test", ConclusionFailure, step("run tests", 2, ConclusionFailure)),
+		job(2, 2, "test", ConclusionFailure, step("run tests", 2, ConclusionFailure)),
+		// Run 3: a single successful attempt
+		job(3, 1, "test", ConclusionSuccess, step("run tests", 2, ConclusionSuccess)),
+		job(3, 1, "build", ConclusionSuccess),
+		// Run 4: cancelled, then successful
+		job(4, 1, "build", "cancelled"),
+		job(4, 2, "build", ConclusionSuccess),
+	}
+
+	fs := WorkflowFlakyParse(wjs)
+
+	assert.Equal(t, 4, fs.RunsCount)
+	assert.Equal(t, 3, fs.RetriedRunsCount)
+	assert.Equal(t, 1, fs.FlakyRunsCount)
+
+	require.Len(t, fs.Jobs, 1)
+	j := fs.Jobs[0]
+	assert.Equal(t, "test", j.Name)
+	assert.Equal(t, 3, j.RunsCount)
+	assert.Equal(t, 1, j.FlakyRunsCount)
+	assert.InDelta(t, 1.0/3, j.FlakeRate, 1e-9)
+	assert.Equal(t, []*FlakyAttempt{{RunID: 1, RunAttempt: 1, HeadSHA: "sha", HTMLURL: "https://github.com/owner/repo/actions/runs/test"}}, j.Attempts)
+	assert.Equal(t, []*FlakyStep{{Name: "run tests", Number: 2, RunsCount: 3, FlakyRunsCount: 1, FlakeRate: 1.0 / 3}}, j.Steps)
+}
+
+func TestWorkflowFlakyParse_Empty(t *testing.T) {
+	assert.Equal(t, &FlakySummary{Jobs: []*FlakyJob{}}, WorkflowFlakyParse(nil))
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// Dimensions the jobs stats are grouped by
+const (
+	// GroupByJob groups the jobs by job name
+	GroupByJob = "job"
+	// GroupByRunnerLabel groups the jobs by their `runs-on` labels
+	GroupByRunnerLabel = "runner-label"
+	// GroupByRunnerGroup groups the jobs by the group of the runner that ran them
+	GroupByRunnerGroup = "runner-group"
+	// GroupByRunnerName groups the jobs by the name of the runner that ran them
+	GroupByRunnerName = "runner-name"
+)
+
+// groupKeySeparator joins the values of the dimensions of a group
+const groupKeySeparator = " / "
+
+// IsValidGroupBy reports whether dimension is a supported grouping dimension of the jobs
+func IsValidGroupBy(dimension string) bool {
+	switch dimension {
+	case GroupByJob, GroupByRunnerLabel, GroupByRunnerGroup, GroupByRunnerName:
+		return true
+	}
+	return false
+}
+
+// jobGroupKey returns the values of the dimensions of a job joined by groupKeySeparator. No dimension groups by job name.
+// With matrix, the job name of a matrix leg is the name of its base job.
+func jobGroupKey(wj *github.WorkflowJob, groupBy []string, matrix bool) string {
+	name := wj.GetName()
+	if matrix {
+		name, _ = ParseMatrixJobName(name)
+	}
+	if len(groupBy) == 0 {
+		return name
+	}
+	values := make([]string, 0, len(groupBy))
+	for _, d := range groupBy {
+		switch d {
+		case GroupByRunnerLabel:
+			values = append(values, runnerLabel(wj))
+		case GroupByRunnerGroup:
+			values = append(values, orUnknownRunner(wj.GetRunnerGroupName()))
+		case GroupByRunnerName:
+			values = append(values, orUnknownRunner(wj.GetRunnerName()))
+		default:
+			values = append(values, name)
+		}
+	}
+	return strings.Join(values, groupKeySeparator)
+}
+
+// runnerLabel returns the `runs-on` labels of a job joined by a comma
+func runnerLabel(wj *github.WorkflowJob) string {
+	return orUnknownRunner(strings.Join(wj.Labels, ","))
+}
+
+func orUnknownRunner(s string) string {
+	if s == "" {
+		return UnknownRunner
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobGroupKey(t *testing.T) {
+	wj := &github.WorkflowJob{
+		Name:            github.String("build"),
+		Labels:          []string{"self-hosted", "linux"},
+		RunnerGroupName: github.String("Default"),
+	}
+
+	tests := []struct {
+		name    string
+		groupBy []string
+		want    string
+	}{
+		{name: "default", groupBy: nil, want: "build"},
+		{name: "job", groupBy: []string{GroupByJob}, want: "build"},
+		{name: "runner label", groupBy: []string{GroupByRunnerLabel}, want: "self-hosted,linux"},
+		{name: "runner group", groupBy: []string{GroupByRunnerGroup}, want: "Default"},
+		{name: "unknown runner name", groupBy: []string{GroupByRunnerName}, want: UnknownRunner},
+		{name: "job and runner label", groupBy: []string{GroupByJob, GroupByRunnerLabel}, want: "build / self-hosted,linux"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, jobGroupKey(wj, tt.groupBy, false))
+		})
+	}
+}
+
+func TestWorkflowJobsParse_GroupBy(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := func(name, runner, conclusion string) *github.WorkflowJob {
+		wj := newJob(name, conclusion, start, time.Minute)
+		wj.RunnerName = github.String(runner)
+		return wj
+	}
+	wjs := []*github.WorkflowJob{
+		job("build", "runner-1", ConclusionSuccess),
+		job("test", "runner-1", ConclusionSuccess),
+		job("build", "runner-2", ConclusionFailure),
+		job("test", "runner-2", ConclusionFailure),
+	}
+
+	jobs := WorkflowJobsParseWithOptions(wjs, ParseOptions{GroupBy: []string{GroupByRunnerName}})
+
+	require.Len(t, jobs, 2)
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+	assert.Equal(t, "runner-1", jobs[0].Name)
+	assert.Equal(t, 2, jobs[0].TotalRunsCount)
+	assert.Equal(t, 1.0, jobs[0].Rate.SuccesRate)
+	assert.Equal(t, "runner-2", jobs[1].Name)
+	assert.Equal(t, 1.0, jobs[1].Rate.FailureRate)
+}
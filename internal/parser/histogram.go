@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// DefaultHistogramBuckets is the number of buckets of a duration histogram
+const DefaultHistogramBuckets = 10
+
+type HistogramBucket struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count int     `json:"count"`
+}
+
+// Histogram counts values in equal-width buckets between the minimum and the maximum value.
+// The upper bound of the last bucket is inclusive. All values fall in a single bucket when they are equal.
+func Histogram(values []float64, buckets int) []HistogramBucket {
+	if len(values) == 0 {
+		return []HistogramBucket{}
+	}
+	if buckets <= 0 {
+		buckets = DefaultHistogramBuckets
+	}
+
+	lo, hi := slices.Min(values), slices.Max(values)
+	if hi-lo < eps {
+		return []HistogramBucket{{Lower: lo, Upper: hi, Count: len(values)}}
+	}
+
+	width := (hi - lo) / float64(buckets)
+	h := make([]HistogramBucket, buckets)
+	for i := range h {
+		h[i].Lower = lo + width*float64(i)
+		h[i].Upper = lo + width*float64(i+1)
+	}
+	h[buckets-1].Upper = hi
+
+	for _, v := range values {
+		i := min(int((v-lo)/width), buckets-1)
+		h[i].Count++
+	}
+	return h
+}
+
+const (
+	// bimodalityThreshold is the bimodality coefficient of a uniform distribution, higher coefficients hint at a bimodal distribution
+	bimodalityThreshold = 5.0 / 9.0
+	// minBimodalSamples is the number of durations below which no bimodality is reported
+	minBimodalSamples = 10
+	// minModeShare is the share of the durations a peak holds at least to count as a mode, so that outliers are no modes
+	minModeShare = 0.1
+)
+
+// DurationHistogram is the distribution of the durations of successful runs or jobs.
+// Bimodal hints that the durations gather around two values, e.g. cache hit and cache miss, Modes are the centers of the two peaks.
+type DurationHistogram struct {
+	Buckets               []HistogramBucket `json:"buckets"`
+	BimodalityCoefficient float64           `json:"bimodality_coefficient"`
+	Bimodal               bool              `json:"bimodal"`
+	Modes                 []float64         `json:"modes,omitempty"`
+}
+
+func durationHistogram(values []float64, buckets int) *DurationHistogram {
+	h := &DurationHistogram{
+		Buckets:               Histogram(values, buckets),
+		BimodalityCoefficient: bimodalityCoefficient(values),
+	}
+	if len(values) < minBimodalSamples || h.BimodalityCoefficient <= bimodalityThreshold {
+		return h
+	}
+	if modes := histogramModes(h.Buckets); modes != nil {
+		h.Bimodal = true
+		h.Modes = modes
+	}
+	return h
+}
+
+// bimodalityCoefficient returns Sarle's bimodality coefficient of the values from their sample skewness and excess kurtosis.
+// It is 0 for less than 4 values or equal values.
+func bimodalityCoefficient(values []float64) float64 {
+	n := float64(len(values))
+	if n < 4 {
+		return 0
+	}
+	mean := calculateMean(values)
+	var m2, m3, m4 float64
+	for _, v := range values {
+		d := v - mean
+		m2 += d * d
+		m3 += d * d * d
+		m4 += d * d * d * d
+	}
+	m2, m3, m4 = m2/n, m3/n, m4/n
+	if m2 < eps {
+		return 0
+	}
+
+	skewness := math.Sqrt(n*(n-1)) / (n - 2) * m3 / math.Pow(m2, 1.5)
+	kurtosis := (n - 1) / ((n - 2) * (n - 3)) * ((n+1)*m4/(m2*m2) - 3*(n-1))
+	return (skewness*skewness + 1) / (kurtosis + 3*(n-1)*(n-1)/((n-2)*(n-3)))
+}
+
+// histogramModes returns the centers of the highest peak of the histogram and of the highest other peak separated from it by a valley
+// lower than half of the smaller peak, or nil when there is no such peak.
+func histogramModes(h []HistogramBucket) []float64 {
+	total := 0
+	for _, b := range h {
+		total += b.Count
+	}
+	peaks := []int{}
+	for i, b := range h {
+		if b.Count > 0 && (i == 0 || b.Count >= h[i-1].Count) && (i == len(h)-1 || b.Count > h[i+1].Count) {
+			peaks = append(peaks, i)
+		}
+	}
+	if len(peaks) < 2 {
+		return nil
+	}
+	sort.SliceStable(peaks, func(i, j int) bool {
+		return h[peaks[i]].Count > h[peaks[j]].Count
+	})
+
+	top := peaks[0]
+	for _, p := range peaks[1:] {
+		if float64(h[p].Count) < minModeShare*float64(total) {
+			break
+		}
+		lo, hi := min(top, p), max(top, p)
+		valley := h[lo+1].Count
+		for _, b := range h[lo+1 : hi] {
+			valley = min(valley, b.Count)
+		}
+		if 2*valley <= min(h[top].Count, h[p].Count) {
+			return []float64{bucketCenter(h[lo]), bucketCenter(h[hi])}
+		}
+	}
+	return nil
+}
+
+func bucketCenter(b HistogramBucket) float64 {
+	return (b.Lower + b.Upper) / 2
+}
@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []float64
+		buckets int
+		want    []HistogramBucket
+	}{
+		{
+			name:    "Empty",
+			values:  []float64{},
+			buckets: 3,
+			want:    []HistogramBucket{},
+		},
+		{
+			name:    "Equal values",
+			values:  []float64{5, 5, 5},
+			buckets: 3,
+			want:    []HistogramBucket{{Lower: 5, Upper: 5, Count: 3}},
+		},
+		{
+			name:    "Equal width buckets",
+			values:  []float64{0, 1, 4, 5, 6, 10, 12},
+			buckets: 3,
+			want: []HistogramBucket{
+				{Lower: 0, Upper: 4, Count: 2},
+				{Lower: 4, Upper: 8, Count: 3},
+				{Lower: 8, Upper: 12, Count: 2},
+			},
+		},
+		{
+			name:    "Default number of buckets",
+			values:  []float64{0, 100},
+			buckets: 0,
+			want: []HistogramBucket{
+				{Lower: 0, Upper: 10, Count: 1},
+				{Lower: 10, Upper: 20},
+				{Lower: 20, Upper: 30},
+				{Lower: 30, Upper: 40},
+				{Lower: 40, Upper: 50},
+				{Lower: 50, Upper: 60},
+				{Lower: 60, Upper: 70},
+				{Lower: 70, Upper: 80},
+				{Lower: 80, Upper: 90},
+				{Lower: 90, Upper: 100, Count: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Histogram(tt.values, tt.buckets))
+		})
+	}
+}
+
+func TestDurationHistogram(t *testing.T) {
+	bimodal := []float64{}
+	for i := 0; i < 10; i++ {
+		bimodal = append(bimodal, 60+float64(i), 600+float64(i))
+	}
+	unimodal := []float64{10, 11, 12, 12, 13, 13, 13, 14, 14, 15, 16, 18, 25}
+
+	tests := []struct {
+		name        string
+		values      []float64
+		wantBimodal bool
+		wantModes   []float64
+	}{
+		{name: "Cache hit and miss", values: bimodal, wantBimodal: true, wantModes: []float64{87.45, 581.55}},
+		{name: "Unimodal", values: unimodal},
+		{name: "Too few durations", values: bimodal[:6]},
+		{name: "Empty", values: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := durationHistogram(tt.values, DefaultHistogramBuckets)
+
+			assert.Equal(t, tt.wantBimodal, h.Bimodal)
+			if !tt.wantBimodal {
+				assert.Nil(t, h.Modes)
+				return
+			}
+			assert.InDeltaSlice(t, tt.wantModes, h.Modes, 0.01)
+			assert.Greater(t, h.BimodalityCoefficient, bimodalityThreshold)
+		})
+	}
+}
+
+func TestBimodalityCoefficient(t *testing.T) {
+	assert.Equal(t, 0.0, bimodalityCoefficient([]float64{1, 2, 3}))
+	assert.Equal(t, 0.0, bimodalityCoefficient([]float64{5, 5, 5, 5}))
+	// Two equal groups of equal values is the most bimodal distribution
+	twoValues := make([]float64, 40)
+	for i := range twoValues[20:] {
+		twoValues[20+i] = 1
+	}
+	assert.InDelta(t, 0.88, bimodalityCoefficient(twoValues), 0.01)
+	assert.Less(t, bimodalityCoefficient([]float64{1, 2, 2, 3, 3, 3, 4, 4, 5}), bimodalityThreshold)
+}
+
+func TestHistogramModes(t *testing.T) {
+	bucket := func(i, count int) HistogramBucket {
+		return HistogramBucket{Lower: float64(i), Upper: float64(i + 1), Count: count}
+	}
+	tests := []struct {
+		name   string
+		counts []int
+		want   []float64
+	}{
+		{name: "Two peaks", counts: []int{1, 8, 2, 0, 1, 6, 1}, want: []float64{1.5, 5.5}},
+		{name: "Shallow valley", counts: []int{1, 8, 5, 6, 1}},
+		{name: "Highest peak separated by a valley", counts: []int{4, 1, 9, 7, 8, 0, 3}, want: []float64{0.5, 2.5}},
+		{name: "Outlier", counts: []int{1, 8, 9, 2, 0, 1}},
+		{name: "Single peak", counts: []int{1, 3, 8, 3, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := make([]HistogramBucket, 0, len(tt.counts))
+			for i, c := range tt.counts {
+				h = append(h, bucket(i, c))
+			}
+			assert.Equal(t, tt.want, histogramModes(h))
+		})
+	}
+}
+
+func TestDurationHistogram_ParseOptions(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	wrs := []*github.WorkflowRun{
+		newRun(1, ConclusionSuccess, start, time.Minute),
+		newRun(2, ConclusionSuccess, start, 3*time.Minute),
+		newRun(3, ConclusionFailure, start, 10*time.Minute),
+	}
+	wjs := []*github.WorkflowJob{
+		newJob("build", ConclusionSuccess, start, time.Minute),
+		newJob("build", ConclusionSuccess, start, 2*time.Minute),
+	}
+
+	assert.Nil(t, WorkflowRunsParseWithOptions(wrs, ParseOptions{}).DurationHistogram)
+	assert.Nil(t, WorkflowJobsParseWithOptions(wjs, ParseOptions{})[0].DurationHistogram)
+
+	opts := ParseOptions{HistogramBuckets: 2}
+	assert.Equal(t, []HistogramBucket{{Lower: 60, Upper: 120, Count: 1}, {Lower: 120, Upper: 180, Count: 1}},
+		WorkflowRunsParseWithOptions(wrs, opts).DurationHistogram.Buckets)
+	assert.Equal(t, []HistogramBucket{{Lower: 60, Upper: 90, Count: 1}, {Lower: 90, Upper: 120, Count: 1}},
+		WorkflowJobsParseWithOptions(wjs, opts)[0].DurationHistogram.Buckets)
+	assert.Equal(t, []HistogramBucket{}, WorkflowRunsParseWithOptions(nil, opts).DurationHistogram.Buckets)
+}
@@ -9,6 +9,8 @@ import (
 type Result struct {
 	WorkflowRunsStatsSummary *WorkflowRunsStatsSummary   `json:"workflow_runs_stats_summary"`
 	WorkflowJobsStatsSummary []*WorkflowJobsStatsSummary `json:"workflow_jobs_stats_summary"`
+	WorkflowJobsQueueSummary *QueueSummary               `json:"workflow_jobs_queue_summary,omitempty"`
+	WorkflowJobsFailureLogs  *FailureLogsSummary         `json:"workflow_jobs_failure_logs,omitempty"`
 }
 
 type WorkflowJobsStatsSummary struct {
@@ -18,6 +20,9 @@ type WorkflowJobsStatsSummary struct {
 	Conclusions            map[string]int         `json:"conclusions"`
 	ExecutionDurationStats ExecutionDurationStats `json:"execution_duration_stats"`
 	StepSummary            []*StepSummary         `json:"steps_summary"`
+	DurationHistogram      *DurationHistogram     `json:"duration_histogram,omitempty"`
+	// Legs are the matrix legs of the job, or the jobs of a matrix axis value
+	Legs []*WorkflowJobsStatsSummary `json:"legs,omitempty"`
 }
 
 type StepSummary struct {
@@ -49,77 +54,42 @@ type StepSummaryCalc struct {
 }
 
 func WorkflowJobsParse(wjs []*github.WorkflowJob) []*WorkflowJobsStatsSummary {
+	return WorkflowJobsParseWithOptions(wjs, ParseOptions{})
+}
+
+// WorkflowJobsParseWithOptions summarizes jobs and their steps and computes the requested percentiles of their durations
+func WorkflowJobsParseWithOptions(wjs []*github.WorkflowJob, opts ParseOptions) []*WorkflowJobsStatsSummary {
+	wjs = sortedJobs(filterJobAttempts(wjs, opts.Attempts))
 	if len(wjs) == 0 {
 		return []*WorkflowJobsStatsSummary{}
 	}
 
+	switch {
+	case opts.MatrixAxis != "":
+		return groupJobsWithLegs(wjs, func(wj *github.WorkflowJob) []string {
+			return matchingAxes(wj.GetName(), opts.MatrixAxis)
+		}, func(wj *github.WorkflowJob) string {
+			return jobGroupKey(wj, opts.GroupBy, true)
+		}, opts)
+	case opts.Matrix:
+		return groupJobsWithLegs(wjs, func(wj *github.WorkflowJob) []string {
+			return []string{jobGroupKey(wj, opts.GroupBy, true)}
+		}, func(wj *github.WorkflowJob) string {
+			return jobGroupKey(wj, opts.GroupBy, false)
+		}, opts)
+	}
+	return groupJobs(wjs, func(wj *github.WorkflowJob) []string {
+		return []string{jobGroupKey(wj, opts.GroupBy, false)}
+	}, opts)
+}
+
+// groupJobs summarizes the jobs of every group. A job belongs to the groups returned by keys.
+func groupJobs(wjs []*github.WorkflowJob, keys func(wj *github.WorkflowJob) []string, opts ParseOptions) []*WorkflowJobsStatsSummary {
 	m := make(map[string]*WorkflowJobsStatsSummaryCalc)
 	for _, wj := range wjs {
-		if _, ok := m[wj.GetName()]; !ok {
-			m[wj.GetName()] = &WorkflowJobsStatsSummaryCalc{
-				TotalRunsCount: 0,
-				Name:           wj.GetName(),
-				Rate:           Rate{},
-				Conclusions: map[string]int{
-					ConclusionSuccess: 0,
-					ConclusionFailure: 0,
-					ConclusionOthers:  0,
-				},
-				ExecutionWorkflowDuration: []float64{},
-				StepSummary:               make(map[string]*StepSummaryCalc),
-			}
-		}
-		w := m[wj.GetName()]
-		w.TotalRunsCount++
-		c := wj.GetConclusion()
-		if c != ConclusionSuccess && c != ConclusionFailure {
-			c = ConclusionOthers
+		for _, key := range keys(wj) {
+			addJob(m, key, wj)
 		}
-		w.Conclusions[c]++
-
-		if wj.GetStatus() == StatusCompleted && c == ConclusionSuccess {
-			d := wj.GetCompletedAt().Sub(wj.GetStartedAt().Time).Seconds()
-			if d < 0 {
-				d = 0
-			}
-			w.ExecutionWorkflowDuration = append(w.ExecutionWorkflowDuration, d)
-		}
-
-		for _, s := range wj.Steps {
-			if _, ok := w.StepSummary[s.GetName()]; !ok {
-				w.StepSummary[s.GetName()] = &StepSummaryCalc{
-					Name:      s.GetName(),
-					Number:    s.GetNumber(),
-					RunsCount: 0,
-					Conclusions: map[string]int{
-						ConclusionSuccess: 0,
-						ConclusionFailure: 0,
-						ConclusionOthers:  0,
-					},
-					FailureHTMLURL: []string{},
-					StepDuration:   []float64{},
-				}
-			}
-			ss := w.StepSummary[s.GetName()]
-			ss.RunsCount++
-			c := s.GetConclusion()
-			if c != ConclusionSuccess && c != ConclusionFailure {
-				c = ConclusionOthers
-			}
-			ss.Conclusions[c]++
-			if s.GetStatus() == StatusCompleted && c == ConclusionFailure {
-				ss.FailureHTMLURL = append(ss.FailureHTMLURL, wj.GetHTMLURL())
-			}
-			if s.GetStatus() == StatusCompleted && (c == ConclusionSuccess || c == ConclusionFailure) {
-				d := s.GetCompletedAt().Sub(s.GetStartedAt().Time).Seconds()
-				if d < 0 {
-					d = 0
-				}
-				ss.StepDuration = append(ss.StepDuration, d)
-			}
-			w.StepSummary[s.GetName()] = ss
-		}
-		m[wj.GetName()] = w
 	}
 
 	res := make([]*WorkflowJobsStatsSummary, 0, len(m))
@@ -130,7 +100,10 @@ func WorkflowJobsParse(wjs []*github.WorkflowJob) []*WorkflowJobsStatsSummary {
 			Rate:                   Rate{},
 			Conclusions:            w.Conclusions,
 			StepSummary:            make([]*StepSummary, 0, len(w.StepSummary)),
-			ExecutionDurationStats: calcStats(w.ExecutionWorkflowDuration),
+			ExecutionDurationStats: calcStats(w.ExecutionWorkflowDuration, opts.Percentiles...),
+		}
+		if opts.HistogramBuckets > 0 {
+			wjs.DurationHistogram = durationHistogram(w.ExecutionWorkflowDuration, opts.HistogramBuckets)
 		}
 		for _, ss := range w.StepSummary {
 			wjs.StepSummary = append(wjs.StepSummary, &StepSummary{
@@ -139,7 +112,7 @@ func WorkflowJobsParse(wjs []*github.WorkflowJob) []*WorkflowJobsStatsSummary {
 				RunsCount:              ss.RunsCount,
 				Conclusions:            ss.Conclusions,
 				FailureHTMLURL:         ss.FailureHTMLURL,
-				ExecutionDurationStats: calcStats(ss.StepDuration),
+				ExecutionDurationStats: calcStats(ss.StepDuration, opts.Percentiles...),
 			})
 		}
 
@@ -162,3 +135,72 @@ func WorkflowJobsParse(wjs []*github.WorkflowJob) []*WorkflowJobsStatsSummary {
 
 	return res
 }
+
+// addJob adds a job to the summary of the group key
+func addJob(m map[string]*WorkflowJobsStatsSummaryCalc, key string, wj *github.WorkflowJob) {
+	if _, ok := m[key]; !ok {
+		m[key] = &WorkflowJobsStatsSummaryCalc{
+			TotalRunsCount: 0,
+			Name:           key,
+			Rate:           Rate{},
+			Conclusions: map[string]int{
+				ConclusionSuccess: 0,
+				ConclusionFailure: 0,
+				ConclusionOthers:  0,
+			},
+			ExecutionWorkflowDuration: []float64{},
+			StepSummary:               make(map[string]*StepSummaryCalc),
+		}
+	}
+	w := m[key]
+	w.TotalRunsCount++
+	c := wj.GetConclusion()
+	if c != ConclusionSuccess && c != ConclusionFailure {
+		c = ConclusionOthers
+	}
+	w.Conclusions[c]++
+
+	if wj.GetStatus() == StatusCompleted && c == ConclusionSuccess {
+		d := wj.GetCompletedAt().Sub(wj.GetStartedAt().Time).Seconds()
+		if d < 0 {
+			d = 0
+		}
+		w.ExecutionWorkflowDuration = append(w.ExecutionWorkflowDuration, d)
+	}
+
+	for _, s := range wj.Steps {
+		if _, ok := w.StepSummary[s.GetName()]; !ok {
+			w.StepSummary[s.GetName()] = &StepSummaryCalc{
+				Name:      s.GetName(),
+				Number:    s.GetNumber(),
+				RunsCount: 0,
+				Conclusions: map[string]int{
+					ConclusionSuccess: 0,
+					ConclusionFailure: 0,
+					ConclusionOthers:  0,
+				},
+				FailureHTMLURL: []string{},
+				StepDuration:   []float64{},
+			}
+		}
+		ss := w.StepSummary[s.GetName()]
+		ss.RunsCount++
+		c := s.GetConclusion()
+		if c != ConclusionSuccess && c != ConclusionFailure {
+			c = ConclusionOthers
+		}
+		ss.Conclusions[c]++
+		if s.GetStatus() == StatusCompleted && c == ConclusionFailure {
+			ss.FailureHTMLURL = append(ss.FailureHTMLURL, wj.GetHTMLURL())
+		}
+		if s.GetStatus() == StatusCompleted && (c == ConclusionSuccess || c == ConclusionFailure) {
+			d := s.GetCompletedAt().Sub(s.GetStartedAt().Time).Seconds()
+			if d < 0 {
+				d = 0
+			}
+			ss.StepDuration = append(ss.StepDuration, d)
+		}
+		w.StepSummary[s.GetName()] = ss
+	}
+	m[key] = w
+}
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"testing"
 
@@ -246,3 +247,29 @@ func TestWorkflowJobsParse(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkflowJobsParse_FailureURLOrder(t *testing.T) {
+	failed := func(runID, attempt int64) *github.WorkflowJob {
+		return &github.WorkflowJob{
+			ID:         github.Int64(runID*10 + attempt),
+			RunID:      github.Int64(runID),
+			RunAttempt: github.Int64(attempt),
+			Name:       github.String("test"),
+			Status:     github.String(StatusCompleted),
+			Conclusion: github.String(ConclusionFailure),
+			HTMLURL:    github.String(fmt.Sprintf("https://github.com/owner/repo/actions/runs/%d/attempts/%d", runID, attempt)),
+			Steps: []*github.TaskStep{
+				{Name: github.String("test"), Number: github.Int64(1), Status: github.String(StatusCompleted), Conclusion: github.String(ConclusionFailure)},
+			},
+		}
+	}
+
+	got := WorkflowJobsParse([]*github.WorkflowJob{failed(3, 1), failed(1, 2), failed(2, 1), failed(1, 1)})
+
+	assert.Equal(t, []string{
+		"https://github.com/owner/repo/actions/runs/1/attempts/1",
+		"https://github.com/owner/repo/actions/runs/1/attempts/2",
+		"https://github.com/owner/repo/actions/runs/2/attempts/1",
+		"https://github.com/owner/repo/actions/runs/3/attempts/1",
+	}, got[0].StepSummary[0].FailureHTMLURL)
+}
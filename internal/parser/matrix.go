@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// matrixJobNameRe matches the name of a matrix leg, "name (axis, axis)"
+var matrixJobNameRe = regexp.MustCompile(`^(.+?) \((.+)\)$`)
+
+// ParseMatrixJobName splits the name of a matrix leg like "build (ubuntu-latest, 1.22)" into its base job name and its axis values.
+// The name of a job that is not a matrix leg is returned as is without axis values.
+func ParseMatrixJobName(name string) (string, []string) {
+	m := matrixJobNameRe.FindStringSubmatch(name)
+	if m == nil {
+		return name, nil
+	}
+	axes := strings.Split(m[2], ",")
+	for i, a := range axes {
+		axes[i] = strings.TrimSpace(a)
+	}
+	return m[1], axes
+}
+
+// IsValidMatrixAxis reports whether pattern is a valid glob pattern of matrix axis values
+func IsValidMatrixAxis(pattern string) bool {
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
+
+// matchingAxes returns the distinct axis values of a matrix leg that match the glob pattern
+func matchingAxes(name, pattern string) []string {
+	_, axes := ParseMatrixJobName(name)
+	matching := make([]string, 0, len(axes))
+	for _, a := range axes {
+		if ok, _ := path.Match(pattern, a); ok && !slices.Contains(matching, a) {
+			matching = append(matching, a)
+		}
+	}
+	return matching
+}
+
+// groupJobsWithLegs summarizes the jobs of every group returned by keys, and the jobs of every group by legKey as its legs.
+// Groups with a single leg of the same name are not broken down.
+func groupJobsWithLegs(wjs []*github.WorkflowJob, keys func(wj *github.WorkflowJob) []string, legKey func(wj *github.WorkflowJob) string, opts ParseOptions) []*WorkflowJobsStatsSummary {
+	members := make(map[string][]*github.WorkflowJob)
+	for _, wj := range wjs {
+		for _, k := range keys(wj) {
+			members[k] = append(members[k], wj)
+		}
+	}
+
+	res := groupJobs(wjs, keys, opts)
+	for _, r := range res {
+		legs := groupJobs(members[r.Name], func(wj *github.WorkflowJob) []string {
+			return []string{legKey(wj)}
+		}, opts)
+		if len(legs) == 1 && legs[0].Name == r.Name {
+			continue
+		}
+		sort.Slice(legs, func(i, j int) bool {
+			return legs[i].Name < legs[j].Name
+		})
+		r.Legs = legs
+	}
+	return res
+}
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMatrixJobName(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantBase string
+		wantAxes []string
+	}{
+		{name: "lint", wantBase: "lint", wantAxes: nil},
+		{name: "build (ubuntu-latest)", wantBase: "build", wantAxes: []string{"ubuntu-latest"}},
+		{name: "test (windows-latest, 1.22)", wantBase: "test", wantAxes: []string{"windows-latest", "1.22"}},
+		{name: "call / e2e (chrome)", wantBase: "call / e2e", wantAxes: []string{"chrome"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, axes := ParseMatrixJobName(tt.name)
+			assert.Equal(t, tt.wantBase, base)
+			assert.Equal(t, tt.wantAxes, axes)
+		})
+	}
+}
+
+func TestWorkflowJobsParse_Matrix(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	wjs := []*github.WorkflowJob{
+		newJob("build (ubuntu-latest)", ConclusionSuccess, start, time.Minute),
+		newJob("build (windows-latest)", ConclusionFailure, start, time.Minute),
+		newJob("build (windows-2019)", ConclusionSuccess, start, 3*time.Minute),
+		newJob("test (windows-latest, 1.22)", ConclusionSuccess, start, 2*time.Minute),
+		newJob("lint", ConclusionSuccess, start, time.Minute),
+	}
+	byName := func(jobs []*WorkflowJobsStatsSummary) {
+		sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+	}
+	names := func(jobs []*WorkflowJobsStatsSummary) []string {
+		ns := []string{}
+		for _, j := range jobs {
+			ns = append(ns, j.Name)
+		}
+		return ns
+	}
+
+	t.Run("roll up", func(t *testing.T) {
+		jobs := WorkflowJobsParseWithOptions(wjs, ParseOptions{Matrix: true})
+		byName(jobs)
+
+		require.Equal(t, []string{"build", "lint", "test"}, names(jobs))
+		assert.Equal(t, 3, jobs[0].TotalRunsCount)
+		assert.Equal(t, []string{"build (ubuntu-latest)", "build (windows-2019)", "build (windows-latest)"}, names(jobs[0].Legs))
+		assert.Equal(t, 1, jobs[0].Legs[2].Conclusions[ConclusionFailure])
+		assert.Nil(t, jobs[1].Legs)
+		assert.Equal(t, []string{"test (windows-latest, 1.22)"}, names(jobs[2].Legs))
+	})
+
+	t.Run("pivot by axis", func(t *testing.T) {
+		jobs := WorkflowJobsParseWithOptions(wjs, ParseOptions{MatrixAxis: "windows-*"})
+		byName(jobs)
+
+		require.Equal(t, []string{"windows-2019", "windows-latest"}, names(jobs))
+		assert.Equal(t, 1, jobs[0].TotalRunsCount)
+		assert.Equal(t, []string{"build"}, names(jobs[0].Legs))
+		assert.Equal(t, 2, jobs[1].TotalRunsCount)
+		assert.Equal(t, []string{"build", "test"}, names(jobs[1].Legs))
+		assert.InDelta(t, 0.5, jobs[1].Rate.SuccesRate, 1e-9)
+	})
+}
+
+func TestIsValidMatrixAxis(t *testing.T) {
+	assert.True(t, IsValidMatrixAxis(""))
+	assert.True(t, IsValidMatrixAxis("windows-*"))
+	assert.False(t, IsValidMatrixAxis("windows-["))
+}
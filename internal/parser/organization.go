@@ -0,0 +1,57 @@
+package parser
+
+import "sort"
+
+type OrganizationStatsSummary struct {
+	Organization        string                    `json:"organization"`
+	RepositoriesCount   int                       `json:"repositories_count"`
+	RateLimitedCount    int                       `json:"rate_limited_count"`
+	FailedCount         int                       `json:"failed_count"`
+	TotalRunsCount      int                       `json:"total_runs_count"`
+	Rate                Rate                      `json:"rate"`
+	Conclusions         map[string]int            `json:"conclusions"`
+	RepositoriesSummary []*RepositoryStatsSummary `json:"repositories_summary"`
+}
+
+// OrganizationParse rolls up the summaries of the repositories of an organization
+func OrganizationParse(org string, repos []*RepositoryStatsSummary) *OrganizationStatsSummary {
+	oss := &OrganizationStatsSummary{
+		Organization: org,
+		Conclusions: map[string]int{
+			ConclusionSuccess: 0,
+			ConclusionFailure: 0,
+			ConclusionOthers:  0,
+		},
+		RepositoriesSummary: make([]*RepositoryStatsSummary, 0, len(repos)),
+	}
+
+	for _, r := range repos {
+		if r == nil {
+			continue
+		}
+		oss.RepositoriesCount++
+		if r.RateLimited {
+			oss.RateLimitedCount++
+		}
+		if r.Error != "" {
+			oss.FailedCount++
+		}
+		oss.TotalRunsCount += r.TotalRunsCount
+		for c, n := range r.Conclusions {
+			oss.Conclusions[c] += n
+		}
+		oss.RepositoriesSummary = append(oss.RepositoriesSummary, r)
+	}
+
+	sort.SliceStable(oss.RepositoriesSummary, func(i, j int) bool {
+		return oss.RepositoriesSummary[i].Repository < oss.RepositoriesSummary[j].Repository
+	})
+
+	if oss.TotalRunsCount > 0 {
+		oss.Rate.SuccesRate = adjustRate(float64(oss.Conclusions[ConclusionSuccess]) / float64(oss.TotalRunsCount))
+		oss.Rate.FailureRate = adjustRate(float64(oss.Conclusions[ConclusionFailure]) / float64(oss.TotalRunsCount))
+		oss.Rate.OthersRate = adjustRate(max(float64(1-oss.Rate.SuccesRate-oss.Rate.FailureRate), 0))
+	}
+
+	return oss
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationParse(t *testing.T) {
+	repos := []*RepositoryStatsSummary{
+		{
+			Repository:     "web",
+			TotalRunsCount: 3,
+			Conclusions:    map[string]int{"success": 2, "failure": 1, "others": 0},
+		},
+		{
+			Repository:     "api",
+			TotalRunsCount: 1,
+			Conclusions:    map[string]int{"success": 0, "failure": 0, "others": 1},
+			RateLimited:    true,
+		},
+		{
+			Repository:  "docs",
+			Conclusions: map[string]int{},
+			Error:       "GitHubAPIError: not found",
+		},
+		nil,
+	}
+
+	got := OrganizationParse("owner", repos)
+
+	assert.Equal(t, "owner", got.Organization)
+	assert.Equal(t, 3, got.RepositoriesCount)
+	assert.Equal(t, 1, got.RateLimitedCount)
+	assert.Equal(t, 1, got.FailedCount)
+	assert.Equal(t, 4, got.TotalRunsCount)
+	assert.Equal(t, map[string]int{"success": 2, "failure": 1, "others": 1}, got.Conclusions)
+	assert.Equal(t, Rate{SuccesRate: 0.5, FailureRate: 0.25, OthersRate: 0.25}, got.Rate)
+	assert.Equal(t, "api", got.RepositoriesSummary[0].Repository)
+	assert.Equal(t, "docs", got.RepositoriesSummary[1].Repository)
+	assert.Equal(t, "web", got.RepositoriesSummary[2].Repository)
+}
+
+func TestOrganizationParse_Empty(t *testing.T) {
+	got := OrganizationParse("owner", nil)
+
+	assert.Equal(t, &OrganizationStatsSummary{
+		Organization:        "owner",
+		Conclusions:         map[string]int{"success": 0, "failure": 0, "others": 0},
+		RepositoriesSummary: []*RepositoryStatsSummary{},
+	}, got)
+}
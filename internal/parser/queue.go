@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// UnknownRunner is the runner label, group or name of jobs without one
+const UnknownRunner = "unknown"
+
+// QueueSummary is the time jobs waited for a runner, from their creation to their start
+type QueueSummary struct {
+	JobsCount          int                    `json:"jobs_count"`
+	TimeZone           string                 `json:"time_zone"`
+	QueueDurationStats ExecutionDurationStats `json:"queue_duration_stats"`
+	Runners            []*QueueBreakdown      `json:"runners"`
+	Hours              []*QueueBreakdown      `json:"hours"`
+}
+
+// QueueBreakdown is the queue time of the jobs of a runner label or of an hour of the day
+type QueueBreakdown struct {
+	Name               string                 `json:"name"`
+	JobsCount          int                    `json:"jobs_count"`
+	QueueDurationStats ExecutionDurationStats `json:"queue_duration_stats"`
+}
+
+// WorkflowJobsQueueParse summarizes the queue time of the jobs, overall, per runner label and per hour of the day in loc.
+// The runner label of a job is its `runs-on` labels joined by a comma. Jobs that were skipped or have not started are ignored.
+func WorkflowJobsQueueParse(wjs []*github.WorkflowJob, loc *time.Location, opts ParseOptions) *QueueSummary {
+	qs := &QueueSummary{
+		TimeZone: loc.String(),
+		Runners:  []*QueueBreakdown{},
+		Hours:    []*QueueBreakdown{},
+	}
+
+	all := []float64{}
+	runners := make(map[string][]float64)
+	hours := make(map[int][]float64)
+	for _, wj := range wjs {
+		if wj.GetConclusion() == "skipped" || wj.CreatedAt == nil || wj.StartedAt == nil {
+			continue
+		}
+		created, started := wj.GetCreatedAt().Time, wj.GetStartedAt().Time
+		if created.IsZero() || started.IsZero() {
+			continue
+		}
+		d := max(started.Sub(created).Seconds(), 0)
+
+		runner := runnerLabel(wj)
+		hour := created.In(loc).Hour()
+
+		all = append(all, d)
+		runners[runner] = append(runners[runner], d)
+		hours[hour] = append(hours[hour], d)
+	}
+
+	qs.JobsCount = len(all)
+	qs.QueueDurationStats = calcStats(all, opts.Percentiles...)
+	for r, d := range runners {
+		qs.Runners = append(qs.Runners, &QueueBreakdown{
+			Name:               r,
+			JobsCount:          len(d),
+			QueueDurationStats: calcStats(d, opts.Percentiles...),
+		})
+	}
+	sort.SliceStable(qs.Runners, func(i, j int) bool {
+		if qs.Runners[i].QueueDurationStats.Med != qs.Runners[j].QueueDurationStats.Med {
+			return qs.Runners[i].QueueDurationStats.Med > qs.Runners[j].QueueDurationStats.Med
+		}
+		return qs.Runners[i].Name < qs.Runners[j].Name
+	})
+
+	for h := 0; h < 24; h++ {
+		d, ok := hours[h]
+		if !ok {
+			continue
+		}
+		qs.Hours = append(qs.Hours, &QueueBreakdown{
+			Name:               time.Date(2000, 1, 1, h, 0, 0, 0, time.UTC).Format("15:04"),
+			JobsCount:          len(d),
+			QueueDurationStats: calcStats(d, opts.Percentiles...),
+		})
+	}
+	return qs
+}
@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowJobsQueueParse(t *testing.T) {
+	created := time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)
+	job := func(created time.Time, queue time.Duration, labels ...string) *github.WorkflowJob {
+		j := newJob("build", ConclusionSuccess, created.Add(queue), time.Minute)
+		j.CreatedAt = &github.Timestamp{Time: created}
+		j.Labels = labels
+		return j
+	}
+	skipped := job(created, time.Hour, "ubuntu-latest")
+	skipped.Conclusion = github.String("skipped")
+	queued := job(created, 0, "ubuntu-latest")
+	queued.StartedAt = nil
+
+	wjs := []*github.WorkflowJob{
+		job(created, 10*time.Second, "ubuntu-latest"),
+		job(created, 20*time.Second, "ubuntu-latest"),
+		job(created.Add(5*time.Hour), 10*time.Minute, "self-hosted", "linux"),
+		job(created.Add(5*time.Hour), 20*time.Minute, "self-hosted", "linux"),
+		// Started before its creation because of clock skew
+		job(created.Add(time.Hour), -time.Second),
+		skipped,
+		queued,
+	}
+
+	qs := WorkflowJobsQueueParse(wjs, time.UTC, ParseOptions{Percentiles: []float64{50}})
+
+	assert.Equal(t, 5, qs.JobsCount)
+	assert.Equal(t, "UTC", qs.TimeZone)
+	assert.Equal(t, 0.0, qs.QueueDurationStats.Min)
+	assert.Equal(t, 1200.0, qs.QueueDurationStats.Max)
+	assert.Equal(t, []Percentile{{P: 50, Value: 20}}, qs.QueueDurationStats.Percentiles)
+
+	require.Len(t, qs.Runners, 3)
+	assert.Equal(t, "self-hosted,linux", qs.Runners[0].Name)
+	assert.Equal(t, 2, qs.Runners[0].JobsCount)
+	assert.Equal(t, 900.0, qs.Runners[0].QueueDurationStats.Med)
+	assert.Equal(t, "ubuntu-latest", qs.Runners[1].Name)
+	assert.Equal(t, UnknownRunner, qs.Runners[2].Name)
+
+	require.Len(t, qs.Hours, 3)
+	assert.Equal(t, []string{"09:00", "10:00", "14:00"}, []string{qs.Hours[0].Name, qs.Hours[1].Name, qs.Hours[2].Name})
+	assert.Equal(t, 15.0, qs.Hours[0].QueueDurationStats.Med)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	qs = WorkflowJobsQueueParse(wjs, tokyo, ParseOptions{})
+	assert.Equal(t, "18:00", qs.Hours[0].Name)
+}
+
+func TestWorkflowJobsQueueParse_Empty(t *testing.T) {
+	qs := WorkflowJobsQueueParse(nil, time.UTC, ParseOptions{})
+	assert.Equal(t, &QueueSummary{TimeZone: "UTC", Runners: []*QueueBreakdown{}, Hours: []*QueueBreakdown{}}, qs)
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"sort"
+
+	"github.com/google/go-github/v60/github"
+)
+
+type RepositoryStatsSummary struct {
+	Repository             string                  `json:"repository,omitempty"`
+	TotalRunsCount         int                     `json:"total_runs_count"`
+	Rate                   Rate                    `json:"rate"`
+	Conclusions            map[string]int          `json:"conclusions"`
+	ExecutionDurationStats ExecutionDurationStats  `json:"execution_duration_stats"`
+	Workflows              []*WorkflowStatsSummary `json:"workflows"`
+	RateLimited            bool                    `json:"rate_limited,omitempty"`
+	Error                  string                  `json:"error,omitempty"`
+}
+
+type WorkflowStatsSummary struct {
+	ID                     int64                  `json:"id"`
+	Name                   string                 `json:"name"`
+	Path                   string                 `json:"path"`
+	State                  string                 `json:"state"`
+	TotalRunsCount         int                    `json:"total_runs_count"`
+	Rate                   Rate                   `json:"rate"`
+	ExecutionDurationStats ExecutionDurationStats `json:"execution_duration_stats"`
+	// Jobs are the stats of the jobs of the workflow, when they were fetched
+	Jobs []*WorkflowJobsStatsSummary `json:"jobs,omitempty"`
+}
+
+// RepositoryParse summarizes the runs of every workflow of a repository and rolls them up.
+// runs and jobs are keyed by workflow ID. A nil jobs skips the job stats.
+// opts apply to every workflow. The jobs of each workflow are set from jobs.
+func RepositoryParse(workflows []*github.Workflow, runs map[int64][]*github.WorkflowRun, jobs map[int64][]*github.WorkflowJob, opts ParseOptions) *RepositoryStatsSummary {
+	rss := &RepositoryStatsSummary{
+		Conclusions: map[string]int{
+			ConclusionSuccess: 0,
+			ConclusionFailure: 0,
+			ConclusionOthers:  0,
+		},
+		Workflows: make([]*WorkflowStatsSummary, 0, len(workflows)),
+	}
+
+	durations := []float64{}
+	for _, wf := range workflows {
+		wopts := opts
+		wopts.Jobs = jobs[wf.GetID()]
+		wrs := WorkflowRunsParseWithOptions(runs[wf.GetID()], wopts)
+		wss := &WorkflowStatsSummary{
+			ID:                     wf.GetID(),
+			Name:                   wf.GetName(),
+			Path:                   wf.GetPath(),
+			State:                  wf.GetState(),
+			TotalRunsCount:         wrs.TotalRunsCount,
+			Rate:                   wrs.Rate,
+			ExecutionDurationStats: wrs.ExecutionDurationStats,
+		}
+		if jobs != nil {
+			wss.Jobs = WorkflowJobsParseWithOptions(jobs[wf.GetID()], opts)
+		}
+		rss.Workflows = append(rss.Workflows, wss)
+
+		rss.TotalRunsCount += wrs.TotalRunsCount
+		for c, wrc := range wrs.Conclusions {
+			rss.Conclusions[c] += wrc.RunsCount
+		}
+		durations = append(durations, SuccessDurations(wrs)...)
+	}
+
+	sort.SliceStable(rss.Workflows, func(i, j int) bool {
+		return rss.Workflows[i].Name < rss.Workflows[j].Name
+	})
+
+	rss.ExecutionDurationStats = calcStats(durations, opts.Percentiles...)
+	if rss.TotalRunsCount > 0 {
+		rss.Rate.SuccesRate = adjustRate(float64(rss.Conclusions[ConclusionSuccess]) / float64(rss.TotalRunsCount))
+		rss.Rate.FailureRate = adjustRate(float64(rss.Conclusions[ConclusionFailure]) / float64(rss.TotalRunsCount))
+		rss.Rate.OthersRate = adjustRate(max(float64(1-rss.Rate.SuccesRate-rss.Rate.FailureRate), 0))
+	}
+
+	return rss
+}
+
+// SuccessDurations returns the durations used for the execution duration stats of a workflow
+func SuccessDurations(wrs *WorkflowRunsStatsSummary) []float64 {
+	d := []float64{}
+	for _, r := range wrs.Conclusions[ConclusionSuccess].WorkflowRuns {
+		if r.Duration > 0 && r.Status == StatusCompleted {
+			d = append(d, r.Duration)
+		}
+	}
+	return d
+}
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRun(id int64, conclusion string, started time.Time, d time.Duration) *github.WorkflowRun {
+	return &github.WorkflowRun{
+		ID:           github.Int64(id),
+		Name:         github.String("CI"),
+		Status:       github.String("completed"),
+		Conclusion:   github.String(conclusion),
+		RunAttempt:   github.Int(1),
+		CreatedAt:    &github.Timestamp{Time: started},
+		RunStartedAt: &github.Timestamp{Time: started},
+		UpdatedAt:    &github.Timestamp{Time: started.Add(d)},
+	}
+}
+
+func TestRepositoryParse(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	workflows := []*github.Workflow{
+		{ID: github.Int64(2), Name: github.String("Release"), Path: github.String(".github/workflows/release.yml"), State: github.String("active")},
+		{ID: github.Int64(1), Name: github.String("CI"), Path: github.String(".github/workflows/ci.yaml"), State: github.String("active")},
+		{ID: github.Int64(3), Name: github.String("Unused"), Path: github.String(".github/workflows/unused.yml"), State: github.String("disabled_manually")},
+	}
+	runs := map[int64][]*github.WorkflowRun{
+		1: {
+			newRun(10, "success", base, 10*time.Second),
+			newRun(11, "success", base, 30*time.Second),
+			newRun(12, "failure", base, 5*time.Second),
+			newRun(13, "cancelled", base, 5*time.Second),
+		},
+		2: {
+			newRun(20, "success", base, 50*time.Second),
+		},
+	}
+
+	got := RepositoryParse(workflows, runs, nil, ParseOptions{})
+
+	assert.Equal(t, 5, got.TotalRunsCount)
+	assert.Equal(t, map[string]int{"success": 3, "failure": 1, "others": 1}, got.Conclusions)
+	assert.InDelta(t, 0.6, got.Rate.SuccesRate, eps)
+	assert.InDelta(t, 0.2, got.Rate.FailureRate, eps)
+	assert.InDelta(t, 0.2, got.Rate.OthersRate, eps)
+	assert.Equal(t, 10.0, got.ExecutionDurationStats.Min)
+	assert.Equal(t, 50.0, got.ExecutionDurationStats.Max)
+	assert.Equal(t, 30.0, got.ExecutionDurationStats.Med)
+
+	assert.Len(t, got.Workflows, 3)
+	assert.Equal(t, []string{"CI", "Release", "Unused"}, []string{got.Workflows[0].Name, got.Workflows[1].Name, got.Workflows[2].Name})
+
+	ci := got.Workflows[0]
+	assert.Equal(t, int64(1), ci.ID)
+	assert.Equal(t, ".github/workflows/ci.yaml", ci.Path)
+	assert.Equal(t, 4, ci.TotalRunsCount)
+	assert.Equal(t, 0.5, ci.Rate.SuccesRate)
+	assert.Equal(t, 0.25, ci.Rate.FailureRate)
+	assert.Equal(t, 20.0, ci.ExecutionDurationStats.Avg)
+
+	unused := got.Workflows[2]
+	assert.Equal(t, "disabled_manually", unused.State)
+	assert.Equal(t, 0, unused.TotalRunsCount)
+	assert.Equal(t, Rate{}, unused.Rate)
+	assert.Nil(t, unused.Jobs)
+}
+
+func TestRepositoryParse_Jobs(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	workflows := []*github.Workflow{
+		{ID: github.Int64(1), Name: github.String("CI")},
+		{ID: github.Int64(2), Name: github.String("Release")},
+	}
+	runs := map[int64][]*github.WorkflowRun{
+		1: {newRun(10, "success", base, time.Minute), newRun(11, "failure", base, time.Minute)},
+	}
+	jobs := map[int64][]*github.WorkflowJob{
+		1: {
+			newJob("build", ConclusionSuccess, base, time.Minute),
+			newJob("build", ConclusionFailure, base, time.Minute),
+			newJob("lint", ConclusionSuccess, base, time.Minute),
+		},
+	}
+
+	got := RepositoryParse(workflows, runs, jobs, ParseOptions{})
+
+	byName := map[string]*WorkflowJobsStatsSummary{}
+	for _, j := range got.Workflows[0].Jobs {
+		byName[j.Name] = j
+	}
+	assert.Len(t, byName, 2)
+	assert.Equal(t, 2, byName["build"].TotalRunsCount)
+	assert.Equal(t, 1, byName["build"].Conclusions[ConclusionFailure])
+	assert.Equal(t, 1, byName["lint"].TotalRunsCount)
+	assert.Equal(t, []*WorkflowJobsStatsSummary{}, got.Workflows[1].Jobs)
+}
+
+func TestRepositoryParse_Options(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	workflows := []*github.Workflow{
+		{ID: github.Int64(1), Name: github.String("CI")},
+		{ID: github.Int64(2), Name: github.String("Release")},
+	}
+	runs := map[int64][]*github.WorkflowRun{
+		1: {newRun(10, "success", base, 10*time.Second), newRun(11, "success", base, 30*time.Second)},
+		2: {newRun(20, "success", base, 50*time.Second)},
+	}
+	jobs := map[int64][]*github.WorkflowJob{
+		1: {newJob("build", ConclusionSuccess, base, time.Minute)},
+	}
+
+	got := RepositoryParse(workflows, runs, jobs, ParseOptions{Percentiles: []float64{50}})
+
+	assert.Equal(t, []Percentile{{P: 50, Value: 30}}, got.ExecutionDurationStats.Percentiles)
+	assert.Equal(t, []Percentile{{P: 50, Value: 20}}, got.Workflows[0].ExecutionDurationStats.Percentiles)
+	assert.Equal(t, []Percentile{{P: 50, Value: 50}}, got.Workflows[1].ExecutionDurationStats.Percentiles)
+	assert.Equal(t, []Percentile{{P: 50, Value: 60}}, got.Workflows[0].Jobs[0].ExecutionDurationStats.Percentiles)
+}
+
+func TestRepositoryParse_Empty(t *testing.T) {
+	got := RepositoryParse(nil, nil, nil, ParseOptions{})
+
+	assert.Equal(t, &RepositoryStatsSummary{
+		Conclusions: map[string]int{"success": 0, "failure": 0, "others": 0},
+		Workflows:   []*WorkflowStatsSummary{},
+	}, got)
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"sort"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// RetrySummary reports how often workflow runs were re-run and what the re-runs cost.
+// Failed attempts are the attempts that did not succeed and were re-run, TimeLost is their execution time in seconds.
+type RetrySummary struct {
+	RunsCount           int              `json:"runs_count"`
+	AttemptsCount       int              `json:"attempts_count"`
+	RerunRunsCount      int              `json:"rerun_runs_count"`
+	RerunRate           float64          `json:"rerun_rate"`
+	FailedAttemptsCount int              `json:"failed_attempts_count"`
+	TimeLost            float64          `json:"time_lost"`
+	Attempts            []*AttemptsCount `json:"attempts"`
+	Actors              []*RerunActor    `json:"actors"`
+}
+
+// AttemptsCount is the number of runs that needed a given number of attempts
+type AttemptsCount struct {
+	Attempts  int `json:"attempts"`
+	RunsCount int `json:"runs_count"`
+}
+
+// RerunActor is a user that triggered re-runs
+type RerunActor struct {
+	Actor       string `json:"actor"`
+	RerunsCount int    `json:"reruns_count"`
+}
+
+// WorkflowRetriesParse groups the attempts of the workflow runs by run ID and reports the re-runs.
+// The duration of the attempts is measured as in WorkflowRunsParseWithOptions.
+func WorkflowRetriesParse(wrs []*github.WorkflowRun, opts ParseOptions) *RetrySummary {
+	rs := &RetrySummary{
+		Attempts: []*AttemptsCount{},
+		Actors:   []*RerunActor{},
+	}
+
+	var timings map[runAttemptKey]jobTiming
+	if opts.DurationMode == DurationModeJobs {
+		timings = jobTimings(opts.Jobs)
+	}
+
+	runs := make(map[int64][]*github.WorkflowRun)
+	for _, wr := range wrs {
+		runs[wr.GetID()] = append(runs[wr.GetID()], wr)
+	}
+
+	attempts := make(map[int]int)
+	actors := make(map[string]int)
+	for _, as := range runs {
+		last := 0
+		for _, wr := range as {
+			last = max(last, wr.GetRunAttempt())
+		}
+		attempts[last]++
+		rs.RunsCount++
+		rs.AttemptsCount += len(as)
+		if last > 1 {
+			rs.RerunRunsCount++
+		}
+
+		for _, wr := range as {
+			if wr.GetRunAttempt() > 1 {
+				actors[rerunActor(wr)]++
+			}
+			if wr.GetRunAttempt() == last || wr.GetConclusion() == ConclusionSuccess {
+				continue
+			}
+			rs.FailedAttemptsCount++
+			d := runDuration(wr)
+			if timings != nil {
+				d = timings[runAttemptKey{runID: wr.GetID(), attempt: int64(wr.GetRunAttempt())}].WallClock
+			}
+			rs.TimeLost += max(d, 0)
+		}
+	}
+	if rs.RunsCount > 0 {
+		rs.RerunRate = float64(rs.RerunRunsCount) / float64(rs.RunsCount)
+	}
+
+	for a, n := range attempts {
+		rs.Attempts = append(rs.Attempts, &AttemptsCount{Attempts: a, RunsCount: n})
+	}
+	sort.Slice(rs.Attempts, func(i, j int) bool {
+		return rs.Attempts[i].Attempts < rs.Attempts[j].Attempts
+	})
+
+	for a, n := range actors {
+		rs.Actors = append(rs.Actors, &RerunActor{Actor: a, RerunsCount: n})
+	}
+	sort.Slice(rs.Actors, func(i, j int) bool {
+		if rs.Actors[i].RerunsCount != rs.Actors[j].RerunsCount {
+			return rs.Actors[i].RerunsCount > rs.Actors[j].RerunsCount
+		}
+		return rs.Actors[i].Actor < rs.Actors[j].Actor
+	})
+	return rs
+}
+
+// rerunActor returns the user that triggered an attempt. The actor of a run is the user that triggered its first attempt.
+func rerunActor(wr *github.WorkflowRun) string {
+	if a := wr.GetTriggeringActor().GetLogin(); a != "" {
+		return a
+	}
+	return wr.GetActor().GetLogin()
+}
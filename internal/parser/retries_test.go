@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowRetriesParse(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	attempt := func(id int64, attempt int, conclusion, triggeringActor string, d time.Duration) *github.WorkflowRun {
+		wr := &github.WorkflowRun{
+			ID:           github.Int64(id),
+			RunAttempt:   github.Int(attempt),
+			Status:       github.String(StatusCompleted),
+			Conclusion:   github.String(conclusion),
+			Actor:        &github.User{Login: github.String("author")},
+			RunStartedAt: &github.Timestamp{Time: start},
+			UpdatedAt:    &github.Timestamp{Time: start.Add(d)},
+		}
+		if triggeringActor != "" {
+			wr.TriggeringActor = &github.User{Login: github.String(triggeringActor)}
+		}
+		return wr
+	}
+
+	wrs := []*github.WorkflowRun{
+		attempt(1, 1, ConclusionSuccess, "", time.Minute),
+		attempt(2, 1, ConclusionFailure, "", 2*time.Minute),
+		attempt(2, 2, ConclusionSuccess, "alice", time.Minute),
+		attempt(3, 1, ConclusionFailure, "", 3*time.Minute),
+		attempt(3, 2, "cancelled", "bob", time.Minute),
+		attempt(3, 3, ConclusionFailure, "alice", time.Minute),
+		attempt(4, 1, ConclusionFailure, "", time.Minute),
+	}
+
+	rs := WorkflowRetriesParse(wrs, ParseOptions{})
+
+	assert.Equal(t, &RetrySummary{
+		RunsCount:           4,
+		AttemptsCount:       7,
+		RerunRunsCount:      2,
+		RerunRate:           0.5,
+		FailedAttemptsCount: 3,
+		TimeLost:            360,
+		Attempts: []*AttemptsCount{
+			{Attempts: 1, RunsCount: 2},
+			{Attempts: 2, RunsCount: 1},
+			{Attempts: 3, RunsCount: 1},
+		},
+		Actors: []*RerunActor{
+			{Actor: "alice", RerunsCount: 2},
+			{Actor: "bob", RerunsCount: 1},
+		},
+	}, rs)
+
+	jobs := []*github.WorkflowJob{runJob(2, 1, start, 30*time.Second)}
+	rs = WorkflowRetriesParse(wrs[:3], ParseOptions{DurationMode: DurationModeJobs, Jobs: jobs})
+	assert.Equal(t, 30.0, rs.TimeLost)
+}
+
+func TestWorkflowRetriesParse_Empty(t *testing.T) {
+	assert.Equal(t, &RetrySummary{Attempts: []*AttemptsCount{}, Actors: []*RerunActor{}}, WorkflowRetriesParse(nil, ParseOptions{}))
+}
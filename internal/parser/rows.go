@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// JobRow is a single execution of a job
+type JobRow struct {
+	RunID       int64     `json:"run_id"`
+	RunAttempt  int64     `json:"run_attempt"`
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	RunnerName  string    `json:"runner_name"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	Duration    float64   `json:"duration"`
+	HTMLURL     string    `json:"html_url"`
+}
+
+// StepRow is a single execution of a step
+type StepRow struct {
+	RunID       int64     `json:"run_id"`
+	RunAttempt  int64     `json:"run_attempt"`
+	JobID       int64     `json:"job_id"`
+	JobName     string    `json:"job_name"`
+	Number      int64     `json:"number"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	Duration    float64   `json:"duration"`
+}
+
+// WorkflowRunRows flattens the runs of every conclusion, ordered by creation time
+func WorkflowRunRows(wrs *WorkflowRunsStatsSummary) []*WorkflowRun {
+	rows := []*WorkflowRun{}
+	for _, c := range []string{ConclusionSuccess, ConclusionFailure, ConclusionOthers} {
+		if wrc, ok := wrs.Conclusions[c]; ok {
+			rows = append(rows, wrc.WorkflowRuns...)
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if !rows[i].CreatedAt.Equal(rows[j].CreatedAt) {
+			return rows[i].CreatedAt.Before(rows[j].CreatedAt)
+		}
+		if rows[i].ID != rows[j].ID {
+			return rows[i].ID < rows[j].ID
+		}
+		return rows[i].RunAttempt < rows[j].RunAttempt
+	})
+	return rows
+}
+
+// WorkflowJobRows returns one row per job execution, ordered by run, attempt and job ID
+func WorkflowJobRows(wjs []*github.WorkflowJob) []*JobRow {
+	rows := make([]*JobRow, 0, len(wjs))
+	for _, wj := range sortedJobs(wjs) {
+		rows = append(rows, &JobRow{
+			RunID:       wj.GetRunID(),
+			RunAttempt:  wj.GetRunAttempt(),
+			ID:          wj.GetID(),
+			Name:        wj.GetName(),
+			Status:      wj.GetStatus(),
+			Conclusion:  wj.GetConclusion(),
+			RunnerName:  wj.GetRunnerName(),
+			StartedAt:   wj.GetStartedAt().UTC(),
+			CompletedAt: wj.GetCompletedAt().UTC(),
+			Duration:    executionDuration(wj.GetStatus(), wj.GetStartedAt(), wj.GetCompletedAt()),
+			HTMLURL:     wj.GetHTMLURL(),
+		})
+	}
+	return rows
+}
+
+// WorkflowStepRows returns one row per step execution, ordered by run, attempt, job ID and step number
+func WorkflowStepRows(wjs []*github.WorkflowJob) []*StepRow {
+	rows := []*StepRow{}
+	for _, wj := range sortedJobs(wjs) {
+		for _, s := range wj.Steps {
+			rows = append(rows, &StepRow{
+				RunID:       wj.GetRunID(),
+				RunAttempt:  wj.GetRunAttempt(),
+				JobID:       wj.GetID(),
+				JobName:     wj.GetName(),
+				Number:      s.GetNumber(),
+				Name:        s.GetName(),
+				Status:      s.GetStatus(),
+				Conclusion:  s.GetConclusion(),
+				StartedAt:   s.GetStartedAt().UTC(),
+				CompletedAt: s.GetCompletedAt().UTC(),
+				Duration:    executionDuration(s.GetStatus(), s.GetStartedAt(), s.GetCompletedAt()),
+			})
+		}
+	}
+	return rows
+}
+
+// sortedJobs returns a copy of the jobs sorted by run ID, run attempt and job ID.
+// Jobs are fetched concurrently, so sorting keeps the order of the rows and the failure URLs stable.
+func sortedJobs(wjs []*github.WorkflowJob) []*github.WorkflowJob {
+	sorted := make([]*github.WorkflowJob, len(wjs))
+	copy(sorted, wjs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].GetRunID() != sorted[j].GetRunID() {
+			return sorted[i].GetRunID() < sorted[j].GetRunID()
+		}
+		if sorted[i].GetRunAttempt() != sorted[j].GetRunAttempt() {
+			return sorted[i].GetRunAttempt() < sorted[j].GetRunAttempt()
+		}
+		return sorted[i].GetID() < sorted[j].GetID()
+	})
+	return sorted
+}
+
+// executionDuration returns the duration of a completed job or step in seconds
+func executionDuration(status string, started, completed github.Timestamp) float64 {
+	if status != StatusCompleted || started.IsZero() || completed.IsZero() {
+		return 0
+	}
+	return max(completed.Sub(started.Time).Seconds(), 0)
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowRunRows(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	wrs := WorkflowRunsParse([]*github.WorkflowRun{
+		newRun(3, "success", start.Add(2*time.Hour), time.Minute),
+		newRun(1, "failure", start, time.Minute),
+		newRun(2, "cancelled", start.Add(time.Hour), time.Minute),
+	})
+
+	got := WorkflowRunRows(wrs)
+
+	require.Len(t, got, 3)
+	assert.Equal(t, []int64{1, 2, 3}, []int64{got[0].ID, got[1].ID, got[2].ID})
+	assert.Equal(t, "cancelled", got[1].Conclusion)
+	assert.Equal(t, 60.0, got[2].Duration)
+}
+
+func TestWorkflowJobRows(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := newJob("test", "failure", start, 30*time.Second)
+	second.ID = github.Int64(20)
+	second.RunID = github.Int64(1)
+	second.RunAttempt = github.Int64(2)
+	first := newJob("build", "success", start, 10*time.Second)
+	first.ID = github.Int64(10)
+	first.RunID = github.Int64(1)
+	first.RunAttempt = github.Int64(1)
+	first.RunnerName = github.String("runner-1")
+	running := &github.WorkflowJob{
+		ID:        github.Int64(30),
+		RunID:     github.Int64(2),
+		Name:      github.String("deploy"),
+		Status:    github.String("in_progress"),
+		StartedAt: &github.Timestamp{Time: start},
+	}
+
+	got := WorkflowJobRows([]*github.WorkflowJob{running, second, first})
+
+	require.Len(t, got, 3)
+	assert.Equal(t, &JobRow{
+		RunID:       1,
+		RunAttempt:  1,
+		ID:          10,
+		Name:        "build",
+		Status:      "completed",
+		Conclusion:  "success",
+		RunnerName:  "runner-1",
+		StartedAt:   start,
+		CompletedAt: start.Add(10 * time.Second),
+		Duration:    10,
+	}, got[0])
+	assert.Equal(t, int64(20), got[1].ID)
+	assert.Equal(t, int64(30), got[2].ID)
+	assert.Equal(t, 0.0, got[2].Duration)
+	assert.True(t, got[2].CompletedAt.IsZero())
+}
+
+func TestWorkflowStepRows(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := newJob("build", "failure", start, time.Minute,
+		newStep("checkout", 1, "success", start, 5*time.Second),
+		newStep("test", 2, "failure", start.Add(5*time.Second), 20*time.Second),
+	)
+	job.ID = github.Int64(10)
+	job.RunID = github.Int64(1)
+	job.RunAttempt = github.Int64(1)
+
+	got := WorkflowStepRows([]*github.WorkflowJob{job, newJob("lint", "success", start, time.Second)})
+
+	require.Len(t, got, 2)
+	assert.Equal(t, &StepRow{
+		RunID:       1,
+		RunAttempt:  1,
+		JobID:       10,
+		JobName:     "build",
+		Number:      2,
+		Name:        "test",
+		Status:      "completed",
+		Conclusion:  "failure",
+		StartedAt:   start.Add(5 * time.Second),
+		CompletedAt: start.Add(25 * time.Second),
+		Duration:    20,
+	}, got[1])
+}
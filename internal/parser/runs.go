@@ -24,6 +24,9 @@ type WorkflowRunsStatsSummary struct {
 	Name                   string                             `json:"name"`
 	Rate                   Rate                               `json:"rate"`
 	ExecutionDurationStats ExecutionDurationStats             `json:"execution_duration_stats"`
+	JobTimeStats           *ExecutionDurationStats            `json:"job_time_stats,omitempty"`
+	AttemptRates           *AttemptRates                      `json:"attempt_rates,omitempty"`
+	DurationHistogram      *DurationHistogram                 `json:"duration_histogram,omitempty"`
 	Conclusions            map[string]*WorkflowRunsConclusion `json:"conclusions"`
 }
 
@@ -51,9 +54,15 @@ type WorkflowRun struct {
 	UpdateAt     time.Time `json:"update_at"`
 	CreatedAt    time.Time `json:"created_at"`
 	Duration     float64   `json:"duration"`
+	JobTime      float64   `json:"job_time,omitempty"`
 }
 
 func WorkflowRunsParse(wrs []*github.WorkflowRun) *WorkflowRunsStatsSummary {
+	return WorkflowRunsParseWithOptions(wrs, ParseOptions{})
+}
+
+// WorkflowRunsParseWithOptions summarizes workflow runs and computes the requested percentiles of their durations
+func WorkflowRunsParseWithOptions(wrs []*github.WorkflowRun, opts ParseOptions) *WorkflowRunsStatsSummary {
 	wfrss := &WorkflowRunsStatsSummary{
 		TotalRunsCount: 0,
 		Conclusions: map[string]*WorkflowRunsConclusion{
@@ -71,12 +80,30 @@ func WorkflowRunsParse(wrs []*github.WorkflowRun) *WorkflowRunsStatsSummary {
 			},
 		},
 	}
+	if opts.Attempts != "" {
+		wfrss.AttemptRates = calcAttemptRates(wrs)
+		wrs = filterRunAttempts(wrs, opts.Attempts)
+	}
 	if len(wrs) == 0 {
+		wfrss.ExecutionDurationStats = calcStats(nil, opts.Percentiles...)
+		if opts.DurationMode == DurationModeJobs {
+			s := calcStats(nil, opts.Percentiles...)
+			wfrss.JobTimeStats = &s
+		}
+		if opts.HistogramBuckets > 0 {
+			wfrss.DurationHistogram = durationHistogram(nil, opts.HistogramBuckets)
+		}
 		return wfrss
 	}
 	wfrss.Name = wrs[0].GetName()
 
+	var timings map[runAttemptKey]jobTiming
+	if opts.DurationMode == DurationModeJobs {
+		timings = jobTimings(opts.Jobs)
+	}
+
 	durations := make([]float64, 0, len(wrs))
+	jobTimes := make([]float64, 0, len(wrs))
 	for _, wr := range wrs {
 		c := wr.GetConclusion()
 		if c != ConclusionSuccess && c != ConclusionFailure {
@@ -99,22 +126,43 @@ func WorkflowRunsParse(wrs []*github.WorkflowRun) *WorkflowRunsStatsSummary {
 			UpdateAt:     wr.GetUpdatedAt().UTC(),
 			CreatedAt:    wr.GetCreatedAt().UTC(),
 		}
-		// TODO: This is not the correct way to calculate the duration. https://github.com/fchimpan/gh-workflow-stats/issues/11
-		d := wr.GetUpdatedAt().Sub(wr.GetRunStartedAt().Time).Seconds()
-		if d > MaxWorkflowDurationSeconds {
-			d = MaxWorkflowDurationCapped
+		d := runDuration(wr)
+		if timings != nil {
+			// Runs without any timed job are left out of the duration stats
+			t := timings[runAttemptKey{runID: wr.GetID(), attempt: int64(wr.GetRunAttempt())}]
+			d = t.WallClock
+			w.JobTime = t.JobTime
 		}
 		w.Duration = d
 		if c == ConclusionSuccess && d > 0 && wr.GetStatus() == StatusCompleted {
 			durations = append(durations, d)
+			jobTimes = append(jobTimes, w.JobTime)
 		}
 		wfrss.Conclusions[c].WorkflowRuns = append(wfrss.Conclusions[c].WorkflowRuns, &w)
 	}
 
-	wfrss.ExecutionDurationStats = calcStats(durations)
+	wfrss.ExecutionDurationStats = calcStats(durations, opts.Percentiles...)
+	if opts.HistogramBuckets > 0 {
+		wfrss.DurationHistogram = durationHistogram(durations, opts.HistogramBuckets)
+	}
+	if timings != nil {
+		s := calcStats(jobTimes, opts.Percentiles...)
+		wfrss.JobTimeStats = &s
+	}
 	wfrss.Rate.SuccesRate = float64(wfrss.Conclusions[ConclusionSuccess].RunsCount) / max(float64(wfrss.TotalRunsCount), 1)
 	wfrss.Rate.FailureRate = float64(wfrss.Conclusions[ConclusionFailure].RunsCount) / max(float64(wfrss.TotalRunsCount), 1)
 	wfrss.Rate.OthersRate = float64(1 - wfrss.Rate.SuccesRate - wfrss.Rate.FailureRate)
 
 	return wfrss
 }
+
+// runDuration returns the duration of a workflow run in seconds.
+// Any edit of the run after its completion inflates it, use the job timings of DurationModeJobs for an accurate duration.
+// See https://github.com/fchimpan/gh-workflow-stats/issues/11
+func runDuration(wr *github.WorkflowRun) float64 {
+	d := wr.GetUpdatedAt().Sub(wr.GetRunStartedAt().Time).Seconds()
+	if d > MaxWorkflowDurationSeconds {
+		d = MaxWorkflowDurationCapped
+	}
+	return d
+}
@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"math"
+	"sort"
+)
+
+// twoProportionZTest returns the two-sided p-value of the difference between the proportions x1/n1 and x2/n2.
+// It returns 1 when the difference cannot be tested.
+func twoProportionZTest(x1, n1, x2, n2 int) float64 {
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+	p1 := float64(x1) / float64(n1)
+	p2 := float64(x2) / float64(n2)
+	pooled := float64(x1+x2) / float64(n1+n2)
+	se := math.Sqrt(pooled * (1 - pooled) * (1/float64(n1) + 1/float64(n2)))
+	if se == 0 {
+		return 1
+	}
+	z := (p1 - p2) / se
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// mannWhitneyUTest returns the two-sided p-value of the Mann-Whitney U test of the samples a and b.
+// The normal approximation with tie correction is used. It returns 1 when the samples cannot be tested.
+func mannWhitneyUTest(a, b []float64) float64 {
+	n1, n2 := len(a), len(b)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	type value struct {
+		v     float64
+		first bool
+	}
+	values := make([]value, 0, n1+n2)
+	for _, v := range a {
+		values = append(values, value{v: v, first: true})
+	}
+	for _, v := range b {
+		values = append(values, value{v: v})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].v < values[j].v
+	})
+
+	// Tied values get the average of their ranks
+	n := float64(n1 + n2)
+	rankSum := 0.0
+	tieSum := 0.0
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j].v == values[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if values[k].first {
+				rankSum += rank
+			}
+		}
+		t := float64(j - i)
+		tieSum += t*t*t - t
+		i = j
+	}
+
+	u := rankSum - float64(n1*(n1+1))/2
+	mean := float64(n1*n2) / 2
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieSum/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+	// Continuity correction
+	z := max(math.Abs(u-mean)-0.5, 0) / math.Sqrt(variance)
+	return math.Erfc(z / math.Sqrt2)
+}
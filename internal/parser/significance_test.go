@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoProportionZTest(t *testing.T) {
+	tests := []struct {
+		name           string
+		x1, n1, x2, n2 int
+		want           float64
+	}{
+		{name: "Empty sample", x1: 0, n1: 0, x2: 5, n2: 10, want: 1},
+		{name: "Same proportions", x1: 5, n1: 10, x2: 50, n2: 100, want: 1},
+		{name: "All successes", x1: 10, n1: 10, x2: 20, n2: 20, want: 1},
+		{name: "Different proportions", x1: 90, n1: 100, x2: 70, n2: 100, want: 0.000407},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, twoProportionZTest(tt.x1, tt.n1, tt.x2, tt.n2), 1e-5)
+		})
+	}
+}
+
+func TestMannWhitneyUTest(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		want float64
+	}{
+		{name: "Empty sample", a: []float64{}, b: []float64{1, 2}, want: 1},
+		{name: "All values tied", a: []float64{1, 1}, b: []float64{1, 1, 1}, want: 1},
+		{name: "Same distribution", a: []float64{1, 3, 5, 7}, b: []float64{2, 4, 6, 8}, want: 0.665006},
+		{name: "Shifted distribution", a: []float64{1, 2, 3, 4, 5, 6, 7, 8}, b: []float64{11, 12, 13, 14, 15, 16, 17, 18}, want: 0.000939},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, mannWhitneyUTest(tt.a, tt.b), 1e-5)
+		})
+	}
+}
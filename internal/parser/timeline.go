@@ -0,0 +1,158 @@
+package parser
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// RunTimeline is the timeline of the jobs of a run attempt.
+// Offsets are in seconds from Start, the creation of the first job. Duration is the time until the last job completed.
+type RunTimeline struct {
+	RunID      int64          `json:"run_id"`
+	RunAttempt int            `json:"run_attempt"`
+	Name       string         `json:"name"`
+	Status     string         `json:"status"`
+	Conclusion string         `json:"conclusion"`
+	HTMLURL    string         `json:"html_url"`
+	Start      time.Time      `json:"start"`
+	Duration   float64        `json:"duration"`
+	Jobs       []*JobTimeline `json:"jobs"`
+}
+
+// JobTimeline is a job on the timeline of a run: queued from QueuedOffset, running from StartedOffset until CompletedOffset.
+// A job that has not started or completed yet ends at the end of the timeline.
+type JobTimeline struct {
+	Name              string          `json:"name"`
+	Status            string          `json:"status"`
+	Conclusion        string          `json:"conclusion"`
+	RunnerName        string          `json:"runner_name"`
+	HTMLURL           string          `json:"html_url"`
+	QueuedOffset      float64         `json:"queued_offset"`
+	StartedOffset     float64         `json:"started_offset"`
+	CompletedOffset   float64         `json:"completed_offset"`
+	QueueDuration     float64         `json:"queue_duration"`
+	ExecutionDuration float64         `json:"execution_duration"`
+	Steps             []*StepTimeline `json:"steps"`
+}
+
+// StepTimeline is a step of a job on the timeline of a run
+type StepTimeline struct {
+	Name            string  `json:"name"`
+	Number          int64   `json:"number"`
+	Conclusion      string  `json:"conclusion"`
+	StartedOffset   float64 `json:"started_offset"`
+	CompletedOffset float64 `json:"completed_offset"`
+	Duration        float64 `json:"duration"`
+}
+
+// WorkflowRunTimelineParse lays out the jobs and steps of a run attempt on a timeline.
+// Jobs of other attempts are ignored. Jobs and steps still in progress run until now.
+func WorkflowRunTimelineParse(wr *github.WorkflowRun, wjs []*github.WorkflowJob, now time.Time) *RunTimeline {
+	tl := &RunTimeline{
+		RunID:      wr.GetID(),
+		RunAttempt: wr.GetRunAttempt(),
+		Name:       wr.GetName(),
+		Status:     wr.GetStatus(),
+		Conclusion: wr.GetConclusion(),
+		HTMLURL:    wr.GetHTMLURL() + "/attempts/" + strconv.Itoa(wr.GetRunAttempt()),
+		Start:      wr.GetRunStartedAt().UTC(),
+		Jobs:       []*JobTimeline{},
+	}
+
+	jobs := make([]*github.WorkflowJob, 0, len(wjs))
+	for _, wj := range wjs {
+		if wj.GetRunID() == wr.GetID() && wj.GetRunAttempt() == int64(wr.GetRunAttempt()) {
+			jobs = append(jobs, wj)
+		}
+	}
+	if len(jobs) == 0 {
+		return tl
+	}
+
+	start, end := jobCreatedAt(jobs[0]), time.Time{}
+	for _, wj := range jobs {
+		if c := jobCreatedAt(wj); c.Before(start) {
+			start = c
+		}
+		if e := timelineEnd(wj.CompletedAt, now); e.After(end) {
+			end = e
+		}
+	}
+	tl.Start = start.UTC()
+	tl.Duration = secondsSince(end, start)
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		ci, cj := jobCreatedAt(jobs[i]), jobCreatedAt(jobs[j])
+		if !ci.Equal(cj) {
+			return ci.Before(cj)
+		}
+		si, sj := timelineEnd(jobs[i].StartedAt, end), timelineEnd(jobs[j].StartedAt, end)
+		if !si.Equal(sj) {
+			return si.Before(sj)
+		}
+		return jobs[i].GetName() < jobs[j].GetName()
+	})
+
+	for _, wj := range jobs {
+		queued := secondsSince(jobCreatedAt(wj), start)
+		started := max(secondsSince(timelineEnd(wj.StartedAt, end), start), queued)
+		completed := max(secondsSince(timelineEnd(wj.CompletedAt, end), start), started)
+		jt := &JobTimeline{
+			Name:              wj.GetName(),
+			Status:            wj.GetStatus(),
+			Conclusion:        wj.GetConclusion(),
+			RunnerName:        wj.GetRunnerName(),
+			HTMLURL:           wj.GetHTMLURL(),
+			QueuedOffset:      queued,
+			StartedOffset:     started,
+			CompletedOffset:   completed,
+			QueueDuration:     started - queued,
+			ExecutionDuration: completed - started,
+			Steps:             make([]*StepTimeline, 0, len(wj.Steps)),
+		}
+		for _, s := range wj.Steps {
+			if s.StartedAt == nil || s.GetStartedAt().IsZero() {
+				continue
+			}
+			ss := min(max(secondsSince(s.GetStartedAt().Time, start), started), completed)
+			sc := min(max(secondsSince(timelineEnd(s.CompletedAt, end), start), ss), completed)
+			jt.Steps = append(jt.Steps, &StepTimeline{
+				Name:            s.GetName(),
+				Number:          s.GetNumber(),
+				Conclusion:      s.GetConclusion(),
+				StartedOffset:   ss,
+				CompletedOffset: sc,
+				Duration:        sc - ss,
+			})
+		}
+		sort.Slice(jt.Steps, func(i, j int) bool {
+			return jt.Steps[i].Number < jt.Steps[j].Number
+		})
+		tl.Jobs = append(tl.Jobs, jt)
+	}
+	return tl
+}
+
+// jobCreatedAt returns the time a job was queued, or its start when the creation time is unknown
+func jobCreatedAt(wj *github.WorkflowJob) time.Time {
+	if t := wj.GetCreatedAt().Time; !t.IsZero() {
+		return t
+	}
+	return wj.GetStartedAt().Time
+}
+
+// timelineEnd returns the time, or the end of the timeline when the time is not known yet
+func timelineEnd(t *github.Timestamp, end time.Time) time.Time {
+	if t == nil || t.IsZero() {
+		return end
+	}
+	return t.Time
+}
+
+// secondsSince returns the seconds from start to t, 0 when t is before start
+func secondsSince(t, start time.Time) float64 {
+	return max(t.Sub(start).Seconds(), 0)
+}
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowRunTimelineParse(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := func(name string, created, started time.Time, d time.Duration, attempt int64, steps ...*github.TaskStep) *github.WorkflowJob {
+		j := newJob(name, ConclusionSuccess, started, d, steps...)
+		j.RunID = github.Int64(1)
+		j.RunAttempt = github.Int64(attempt)
+		j.CreatedAt = &github.Timestamp{Time: created}
+		return j
+	}
+	wr := &github.WorkflowRun{
+		ID:         github.Int64(1),
+		Name:       github.String("CI"),
+		RunAttempt: github.Int(2),
+		Status:     github.String(StatusCompleted),
+		Conclusion: github.String(ConclusionSuccess),
+		HTMLURL:    github.String("https://github.com/owner/repo/actions/runs/1"),
+	}
+
+	running := job("deploy", start.Add(5*time.Minute), start.Add(6*time.Minute), 0, 2,
+		newStep("setup", 1, ConclusionSuccess, start.Add(6*time.Minute), time.Minute),
+	)
+	running.Status = github.String("in_progress")
+	running.Conclusion = nil
+	running.CompletedAt = nil
+	running.Steps = append(running.Steps, &github.TaskStep{Name: github.String("deploy"), Number: github.Int64(2), StartedAt: &github.Timestamp{Time: start.Add(7 * time.Minute)}})
+
+	wjs := []*github.WorkflowJob{
+		job("test", start.Add(time.Minute), start.Add(2*time.Minute), 3*time.Minute, 2,
+			newStep("test", 2, ConclusionSuccess, start.Add(3*time.Minute), 2*time.Minute),
+			newStep("checkout", 1, ConclusionSuccess, start.Add(2*time.Minute), time.Minute),
+			&github.TaskStep{Name: github.String("cleanup"), Number: github.Int64(3)},
+		),
+		job("build", start, start.Add(30*time.Second), time.Minute, 2),
+		job("build", start, start, time.Minute, 1),
+		running,
+	}
+
+	tl := WorkflowRunTimelineParse(wr, wjs, start.Add(10*time.Minute))
+
+	assert.Equal(t, &RunTimeline{
+		RunID:      1,
+		RunAttempt: 2,
+		Name:       "CI",
+		Status:     StatusCompleted,
+		Conclusion: ConclusionSuccess,
+		HTMLURL:    "https://github.com/owner/repo/actions/runs/1/attempts/2",
+		Start:      start,
+		Duration:   600,
+		Jobs: []*JobTimeline{
+			{Name: "build", Status: StatusCompleted, Conclusion: ConclusionSuccess, QueuedOffset: 0, StartedOffset: 30, CompletedOffset: 90, QueueDuration: 30, ExecutionDuration: 60, Steps: []*StepTimeline{}},
+			{Name: "test", Status: StatusCompleted, Conclusion: ConclusionSuccess, QueuedOffset: 60, StartedOffset: 120, CompletedOffset: 300, QueueDuration: 60, ExecutionDuration: 180, Steps: []*StepTimeline{
+				{Name: "checkout", Number: 1, Conclusion: ConclusionSuccess, StartedOffset: 120, CompletedOffset: 180, Duration: 60},
+				{Name: "test", Number: 2, Conclusion: ConclusionSuccess, StartedOffset: 180, CompletedOffset: 300, Duration: 120},
+			}},
+			{Name: "deploy", Status: "in_progress", QueuedOffset: 300, StartedOffset: 360, CompletedOffset: 600, QueueDuration: 60, ExecutionDuration: 240, Steps: []*StepTimeline{
+				{Name: "setup", Number: 1, Conclusion: ConclusionSuccess, StartedOffset: 360, CompletedOffset: 420, Duration: 60},
+				{Name: "deploy", Number: 2, StartedOffset: 420, CompletedOffset: 600, Duration: 180},
+			}},
+		},
+	}, tl)
+}
+
+func TestWorkflowRunTimelineParse_NoJobs(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	wr := &github.WorkflowRun{
+		ID:           github.Int64(1),
+		RunAttempt:   github.Int(1),
+		RunStartedAt: &github.Timestamp{Time: start},
+	}
+
+	tl := WorkflowRunTimelineParse(wr, nil, start)
+
+	assert.Equal(t, start, tl.Start)
+	assert.Equal(t, 0.0, tl.Duration)
+	assert.Empty(t, tl.Jobs)
+}
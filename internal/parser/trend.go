@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+)
+
+const (
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+type WorkflowRunsTrendSummary struct {
+	Name     string         `json:"name"`
+	Period   string         `json:"period"`
+	TimeZone string         `json:"time_zone"`
+	Buckets  []*TrendBucket `json:"buckets"`
+}
+
+type TrendBucket struct {
+	Start                  time.Time          `json:"start"`
+	End                    time.Time          `json:"end"`
+	TotalRunsCount         int                `json:"total_runs_count"`
+	Conclusions            map[string]int     `json:"conclusions"`
+	Rate                   Rate               `json:"rate"`
+	ExecutionDurationStats TrendDurationStats `json:"execution_duration_stats"`
+}
+
+// TrendDurationStats is the duration summary of the successful runs of a bucket
+type TrendDurationStats struct {
+	Min float64 `json:"min"`
+	Med float64 `json:"med"`
+	P95 float64 `json:"p95"`
+}
+
+// IsValidPeriod returns true if runs can be bucketed by the period
+func IsValidPeriod(period string) bool {
+	switch period {
+	case PeriodDay, PeriodWeek, PeriodMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+// WorkflowRunsTrendParse buckets workflow runs by their creation time in the given location.
+// Buckets without runs between the first and the last run are included so that the series has no gaps.
+func WorkflowRunsTrendParse(wrs []*github.WorkflowRun, period string, loc *time.Location) (*WorkflowRunsTrendSummary, error) {
+	if !IsValidPeriod(period) {
+		return nil, fmt.Errorf("invalid period %q: must be one of %s, %s or %s", period, PeriodDay, PeriodWeek, PeriodMonth)
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	ts := &WorkflowRunsTrendSummary{
+		Period:   period,
+		TimeZone: loc.String(),
+		Buckets:  []*TrendBucket{},
+	}
+	if len(wrs) == 0 {
+		return ts, nil
+	}
+	ts.Name = wrs[0].GetName()
+
+	type calc struct {
+		bucket    *TrendBucket
+		durations []float64
+	}
+	m := make(map[int64]*calc)
+	var first, last time.Time
+	for _, wr := range wrs {
+		start := bucketStart(wr.GetCreatedAt().In(loc), period)
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+		if start.After(last) {
+			last = start
+		}
+		if _, ok := m[start.Unix()]; !ok {
+			m[start.Unix()] = &calc{bucket: newTrendBucket(start, period)}
+		}
+		b := m[start.Unix()]
+
+		c := wr.GetConclusion()
+		if c != ConclusionSuccess && c != ConclusionFailure {
+			c = ConclusionOthers
+		}
+		b.bucket.TotalRunsCount++
+		b.bucket.Conclusions[c]++
+
+		if d := runDuration(wr); c == ConclusionSuccess && d > 0 && wr.GetStatus() == StatusCompleted {
+			b.durations = append(b.durations, d)
+		}
+	}
+
+	for start := first; !start.After(last); start = bucketEnd(start, period) {
+		b, ok := m[start.Unix()]
+		if !ok {
+			ts.Buckets = append(ts.Buckets, newTrendBucket(start, period))
+			continue
+		}
+
+		total := float64(b.bucket.TotalRunsCount)
+		b.bucket.Rate.SuccesRate = adjustRate(float64(b.bucket.Conclusions[ConclusionSuccess]) / total)
+		b.bucket.Rate.FailureRate = adjustRate(float64(b.bucket.Conclusions[ConclusionFailure]) / total)
+		b.bucket.Rate.OthersRate = adjustRate(max(float64(1-b.bucket.Rate.SuccesRate-b.bucket.Rate.FailureRate), 0))
+
+		if len(b.durations) > 0 {
+			sort.Float64s(b.durations)
+			b.bucket.ExecutionDurationStats = TrendDurationStats{
+				Min: b.durations[0],
+				Med: calculatePercentile(b.durations, 50),
+				P95: calculatePercentile(b.durations, 95),
+			}
+		}
+		ts.Buckets = append(ts.Buckets, b.bucket)
+	}
+
+	return ts, nil
+}
+
+func newTrendBucket(start time.Time, period string) *TrendBucket {
+	return &TrendBucket{
+		Start: start,
+		End:   bucketEnd(start, period),
+		Conclusions: map[string]int{
+			ConclusionSuccess: 0,
+			ConclusionFailure: 0,
+			ConclusionOthers:  0,
+		},
+	}
+}
+
+// bucketStart truncates t to the start of its period. Weeks start on Monday.
+func bucketStart(t time.Time, period string) time.Time {
+	y, m, d := t.Date()
+	switch period {
+	case PeriodWeek:
+		offset := (int(t.Weekday()) + 6) % 7
+		return startOfDay(y, m, d-offset, t.Location())
+	case PeriodMonth:
+		return startOfDay(y, m, 1, t.Location())
+	default:
+		return startOfDay(y, m, d, t.Location())
+	}
+}
+
+// bucketEnd returns the start of the next period.
+// It is computed from the calendar date so that it matches bucketStart of the runs of the next period in every time zone.
+func bucketEnd(start time.Time, period string) time.Time {
+	y, m, d := start.Date()
+	switch period {
+	case PeriodWeek:
+		return startOfDay(y, m, d+7, start.Location())
+	case PeriodMonth:
+		return startOfDay(y, m+1, 1, start.Location())
+	default:
+		return startOfDay(y, m, d+1, start.Location())
+	}
+}
+
+// startOfDay returns the first instant of a day. Where midnight is skipped by a DST change, e.g. America/Santiago on 2024-09-08, the day starts at the end of the gap.
+func startOfDay(y int, m time.Month, d int, loc *time.Location) time.Time {
+	start := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	noon := time.Date(y, m, d, 12, 0, 0, 0, loc)
+	if start.Day() != noon.Day() {
+		_, before := start.Zone()
+		_, after := noon.Zone()
+		start = start.Add(time.Duration(after-before) * time.Second)
+	}
+	return start
+}
@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowRunsTrendParse(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2024, 1, d, h, 0, 0, 0, time.UTC) }
+	runs := []*github.WorkflowRun{
+		newRun(1, "success", day(1, 10), 10*time.Second),
+		newRun(2, "success", day(1, 12), 30*time.Second),
+		newRun(3, "failure", day(1, 23), 5*time.Second),
+		newRun(4, "success", day(3, 1), 20*time.Second),
+		newRun(5, "cancelled", day(8, 9), 20*time.Second),
+	}
+
+	t.Run("Day", func(t *testing.T) {
+		got, err := WorkflowRunsTrendParse(runs, PeriodDay, time.UTC)
+		require.NoError(t, err)
+
+		assert.Equal(t, "CI", got.Name)
+		assert.Equal(t, "UTC", got.TimeZone)
+		require.Len(t, got.Buckets, 8, "days without runs are included")
+
+		first := got.Buckets[0]
+		assert.Equal(t, day(1, 0), first.Start)
+		assert.Equal(t, day(2, 0), first.End)
+		assert.Equal(t, 3, first.TotalRunsCount)
+		assert.Equal(t, map[string]int{"success": 2, "failure": 1, "others": 0}, first.Conclusions)
+		assert.InDelta(t, 2.0/3, first.Rate.SuccesRate, eps)
+		assert.Equal(t, TrendDurationStats{Min: 10, Med: 20, P95: 29}, first.ExecutionDurationStats)
+
+		assert.Equal(t, 0, got.Buckets[1].TotalRunsCount)
+		assert.Equal(t, Rate{}, got.Buckets[1].Rate)
+		assert.Equal(t, 1, got.Buckets[2].TotalRunsCount)
+		assert.Equal(t, 1.0, got.Buckets[7].Rate.OthersRate)
+	})
+
+	t.Run("Week starts on Monday", func(t *testing.T) {
+		got, err := WorkflowRunsTrendParse(runs, PeriodWeek, time.UTC)
+		require.NoError(t, err)
+
+		require.Len(t, got.Buckets, 2)
+		assert.Equal(t, day(1, 0), got.Buckets[0].Start)
+		assert.Equal(t, 4, got.Buckets[0].TotalRunsCount)
+		assert.Equal(t, day(8, 0), got.Buckets[1].Start)
+		assert.Equal(t, 1, got.Buckets[1].TotalRunsCount)
+	})
+
+	t.Run("Month", func(t *testing.T) {
+		got, err := WorkflowRunsTrendParse(runs, PeriodMonth, time.UTC)
+		require.NoError(t, err)
+
+		require.Len(t, got.Buckets, 1)
+		assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), got.Buckets[0].End)
+		assert.Equal(t, 5, got.Buckets[0].TotalRunsCount)
+	})
+
+	t.Run("Time zone", func(t *testing.T) {
+		loc := time.FixedZone("UTC+9", 9*60*60)
+		got, err := WorkflowRunsTrendParse(runs, PeriodDay, loc)
+		require.NoError(t, err)
+
+		assert.Equal(t, "UTC+9", got.TimeZone)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, loc), got.Buckets[0].Start)
+		assert.Equal(t, 2, got.Buckets[0].TotalRunsCount)
+		assert.Equal(t, 1, got.Buckets[1].TotalRunsCount, "23:00 UTC is the next day in UTC+9")
+	})
+}
+
+func TestWorkflowRunsTrendParse_SkippedMidnight(t *testing.T) {
+	// Midnight of 2024-09-08 is skipped in Santiago: the clocks jump from 00:00 to 01:00
+	loc, err := time.LoadLocation("America/Santiago")
+	require.NoError(t, err)
+	at := func(d, h int) time.Time { return time.Date(2024, 9, d, h, 0, 0, 0, loc) }
+	runs := []*github.WorkflowRun{
+		newRun(1, "success", at(7, 12), 10*time.Second),
+		newRun(2, "success", at(8, 12), 10*time.Second),
+		newRun(3, "success", at(9, 12), 10*time.Second),
+		newRun(4, "failure", at(10, 12), 10*time.Second),
+	}
+
+	got, err := WorkflowRunsTrendParse(runs, PeriodDay, loc)
+	require.NoError(t, err)
+
+	require.Len(t, got.Buckets, 4)
+	for i, b := range got.Buckets {
+		assert.Equal(t, 1, b.TotalRunsCount, "bucket %d", i)
+		if i > 0 {
+			assert.Equal(t, got.Buckets[i-1].End, b.Start)
+		}
+	}
+	assert.Equal(t, at(8, 1), got.Buckets[1].Start)
+	assert.Equal(t, at(9, 0), got.Buckets[1].End)
+	assert.Equal(t, at(10, 0), got.Buckets[3].Start)
+}
+
+func TestWorkflowRunsTrendParse_Empty(t *testing.T) {
+	got, err := WorkflowRunsTrendParse(nil, PeriodWeek, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, &WorkflowRunsTrendSummary{Period: PeriodWeek, TimeZone: "UTC", Buckets: []*TrendBucket{}}, got)
+}
+
+func TestWorkflowRunsTrendParse_InvalidPeriod(t *testing.T) {
+	_, err := WorkflowRunsTrendParse(nil, "year", time.UTC)
+
+	assert.Error(t, err)
+}
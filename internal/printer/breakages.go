@@ -0,0 +1,62 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+const (
+	breakageTimeFormat = "2006-01-02 15:04 MST"
+	shortSHALength     = 7
+)
+
+func Breakages(w io.Writer, bs *parser.BreakageSummary, n int) {
+	_, _ = fmt.Fprintf(w, "%s Breakages of %s: %d in %d commits\n", "\U0001F6A8", bs.Branch, bs.BreakagesCount, bs.CommitsCount)
+	if r := bs.Reliability; !r.Since.IsZero() {
+		_, _ = fmt.Fprintf(w, "\n%s Reliability since %s\n", "\U0001F4CF", r.Since.Format(breakageTimeFormat))
+		_, _ = fmt.Fprintf(w, "  Red time: %s (%.1f%%)\n", formatSeconds(r.RedTime), r.RedTimeRate*100)
+		_, _ = fmt.Fprintf(w, "  Red streaks: %d\n", r.RedStreaksCount)
+		_, _ = fmt.Fprintf(w, "  Mean time to recovery: %s\n", formatSeconds(r.MeanTimeToRecovery))
+		_, _ = fmt.Fprintf(w, "  Median time to recovery: %s\n", formatSeconds(r.MedianTimeToRecovery))
+		_, _ = fmt.Fprintf(w, "  Longest outage: %s\n", formatSeconds(r.LongestOutage))
+	}
+	if len(bs.Breakages) == 0 {
+		return
+	}
+
+	red := color.New(color.FgRed).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	breakagesNum := min(len(bs.Breakages), n)
+	_, _ = fmt.Fprintf(w, "\n%s Latest %d breakages\n", "\U0001F4C9", breakagesNum)
+	for _, b := range bs.Breakages[:breakagesNum] {
+		d := formatSeconds(b.Duration)
+		_, _ = fmt.Fprintf(w, "  %s by %s at %s (%d failed commits)\n", shortSHA(b.SHA), cyan(b.Actor), b.StartedAt.Format(breakageTimeFormat), b.FailedCommitsCount)
+		if b.Ongoing {
+			_, _ = fmt.Fprintf(w, "    ├──%s\n", red("still red for "+d))
+		} else {
+			_, _ = fmt.Fprintf(w, "    ├──%s by %s in %s\n", green("red for "+d+", fixed"), cyan(b.FixedBy), shortSHA(b.FixedSHA))
+		}
+		_, _ = fmt.Fprintf(w, "    └──%s\n", b.HTMLURL)
+	}
+
+	actorsNum := min(len(bs.Actors), n)
+	_, _ = fmt.Fprintf(w, "\n%s Top %d actors breaking %s\n", "\U0001F64B", actorsNum, bs.Branch)
+	for _, a := range bs.Actors[:actorsNum] {
+		_, _ = fmt.Fprintf(w, "  %s: %d\n", cyan(a.Actor), a.BreakagesCount)
+	}
+}
+
+// formatSeconds formats a duration in seconds rounded to the second, e.g. "1h2m5s"
+func formatSeconds(s float64) string {
+	return time.Duration(s * float64(time.Second)).Round(time.Second).String()
+}
+
+func shortSHA(sha string) string {
+	return sha[:min(len(sha), shortSHALength)]
+}
@@ -0,0 +1,56 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreakages(t *testing.T) {
+	started := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	fixedAt := started.Add(2 * time.Hour)
+	bs := &parser.BreakageSummary{
+		Branch:         "main",
+		CommitsCount:   12,
+		BreakagesCount: 2,
+		Reliability: parser.ReliabilityStats{
+			Since:                started.Add(-time.Hour),
+			Period:               36000,
+			RedTime:              9000,
+			RedTimeRate:          0.25,
+			RedStreaksCount:      2,
+			MeanTimeToRecovery:   7200,
+			MedianTimeToRecovery: 7200,
+			LongestOutage:        7200,
+		},
+		Breakages: []*parser.Breakage{
+			{SHA: "c6a1b2c3d4", Actor: "carol", StartedAt: started.Add(5 * time.Hour), HTMLURL: "https://example.com/2", FailedCommitsCount: 1, Ongoing: true, Duration: 1800},
+			{SHA: "c2a1b2c3d4", Actor: "alice", StartedAt: started, HTMLURL: "https://example.com/1", FailedCommitsCount: 3, FixedSHA: "c4a1b2c3d4", FixedBy: "bob", FixedAt: &fixedAt, Duration: 7200},
+		},
+		Actors: []*parser.BreakageActor{{Actor: "alice", BreakagesCount: 1}, {Actor: "carol", BreakagesCount: 1}},
+	}
+
+	w := &bytes.Buffer{}
+	Breakages(w, bs, 3)
+
+	assert.Equal(t, "🚨 Breakages of main: 2 in 12 commits\n"+
+		"\n📏 Reliability since 2024-01-01 09:00 UTC\n"+
+		"  Red time: 2h30m0s (25.0%)\n"+
+		"  Red streaks: 2\n"+
+		"  Mean time to recovery: 2h0m0s\n"+
+		"  Median time to recovery: 2h0m0s\n"+
+		"  Longest outage: 2h0m0s\n"+
+		"\n📉 Latest 2 breakages\n"+
+		"  c6a1b2c by carol at 2024-01-01 15:00 UTC (1 failed commits)\n"+
+		"    ├──still red for 30m0s\n"+
+		"    └──https://example.com/2\n"+
+		"  c2a1b2c by alice at 2024-01-01 10:00 UTC (3 failed commits)\n"+
+		"    ├──red for 2h0m0s, fixed by bob in c4a1b2c\n"+
+		"    └──https://example.com/1\n"+
+		"\n🙋 Top 2 actors breaking main\n"+
+		"  alice: 1\n"+
+		"  carol: 1\n", w.String())
+}
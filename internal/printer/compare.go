@@ -0,0 +1,56 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+func Compare(w io.Writer, cs *parser.ComparisonSummary) {
+	_, _ = fmt.Fprintf(w, "%s  Comparing base (%s) with head (%s)\n", "⚖", cs.Base.Label, cs.Head.Label)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  Name\tRuns\tSuccess\tMed\tP95")
+	for _, d := range cs.Diff {
+		_, _ = fmt.Fprintf(tw, "  %s\t%d → %d\t%.1f%% → %.1f%% (%+.1fpp)%s\t%.1fs → %.1fs (%+.1fs)%s\t%.1fs → %.1fs (%+.1fs)\n",
+			diffName(d),
+			d.Base.RunsCount,
+			d.Head.RunsCount,
+			d.Base.SuccesRate*100,
+			d.Head.SuccesRate*100,
+			d.SuccessRateDelta*100,
+			significanceMark(d.SuccessRateSignificant),
+			d.Base.Med,
+			d.Head.Med,
+			d.MedDelta,
+			significanceMark(d.DurationSignificant),
+			d.Base.P95,
+			d.Head.P95,
+			d.P95Delta,
+		)
+	}
+	_ = tw.Flush()
+
+	_, _ = fmt.Fprintf(w, "\n  * significant change (p < %g). Success rates are compared with a two-proportion z-test, durations with a Mann-Whitney U test.\n", cs.Alpha)
+}
+
+// diffName indents jobs and steps under their workflow
+func diffName(d *parser.ComparisonDiff) string {
+	switch d.Kind {
+	case parser.DeltaKindJob:
+		return "  " + d.Name
+	case parser.DeltaKindStep:
+		return "    └─ " + d.Name
+	default:
+		return d.Name
+	}
+}
+
+func significanceMark(significant bool) string {
+	if significant {
+		return " *"
+	}
+	return ""
+}
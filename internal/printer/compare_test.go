@@ -0,0 +1,54 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	cs := &parser.ComparisonSummary{
+		Alpha: 0.05,
+		Base:  &parser.ComparisonSide{Label: "branch=main"},
+		Head:  &parser.ComparisonSide{Label: "branch=feature"},
+		Diff: []*parser.ComparisonDiff{
+			{
+				Kind:                   parser.DeltaKindWorkflow,
+				Name:                   "CI",
+				Base:                   parser.ComparisonSample{RunsCount: 20, SuccesRate: 1, Med: 19.5, P95: 28},
+				Head:                   parser.ComparisonSample{RunsCount: 20, SuccesRate: 0.5, Med: 40, P95: 48},
+				SuccessRateDelta:       -0.5,
+				SuccessRateSignificant: true,
+				MedDelta:               20.5,
+				P95Delta:               20,
+				DurationSignificant:    true,
+			},
+			{
+				Kind:     parser.DeltaKindJob,
+				Name:     "build",
+				Base:     parser.ComparisonSample{RunsCount: 20, SuccesRate: 1, Med: 10, P95: 12},
+				Head:     parser.ComparisonSample{RunsCount: 20, SuccesRate: 1, Med: 9, P95: 12},
+				MedDelta: -1,
+			},
+			{
+				Kind: parser.DeltaKindStep,
+				Name: "test",
+				Job:  "build",
+				Base: parser.ComparisonSample{RunsCount: 20, SuccesRate: 1},
+				Head: parser.ComparisonSample{RunsCount: 20, SuccesRate: 1},
+			},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	Compare(w, cs)
+
+	assert.Equal(t, "⚖  Comparing base (branch=main) with head (branch=feature)\n"+
+		"  Name         Runs     Success                     Med                       P95\n"+
+		"  CI           20 → 20  100.0% → 50.0% (-50.0pp) *  19.5s → 40.0s (+20.5s) *  28.0s → 48.0s (+20.0s)\n"+
+		"    build      20 → 20  100.0% → 100.0% (+0.0pp)    10.0s → 9.0s (-1.0s)      12.0s → 12.0s (+0.0s)\n"+
+		"      └─ test  20 → 20  100.0% → 100.0% (+0.0pp)    0.0s → 0.0s (+0.0s)       0.0s → 0.0s (+0.0s)\n"+
+		"\n  * significant change (p < 0.05). Success rates are compared with a two-proportion z-test, durations with a Mann-Whitney U test.\n", w.String())
+}
@@ -0,0 +1,35 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+func Cost(w io.Writer, cs *parser.CostSummary) {
+	_, _ = fmt.Fprintf(w, "%s Estimated cost: $%.2f (%d runs, $%.4f per run)\n", "\U0001F4B0", cs.Cost, cs.TotalRunsCount, cs.CostPerRun)
+	_, _ = fmt.Fprintf(w, "  Jobs: %d\n", cs.JobsCount)
+	_, _ = fmt.Fprintf(w, "  Minutes: %.0f\n", cs.Minutes)
+	_, _ = fmt.Fprintf(w, "  Billable minutes: %.0f\n", cs.BillableMinutes)
+
+	costTable(w, fmt.Sprintf("\n%s Cost by workflow\n", "\U0001F5C2"), "Workflow", cs.Workflows)
+	costTable(w, fmt.Sprintf("\n%s Cost by job\n", "\U0001F4CA"), "Job", cs.Jobs)
+	costTable(w, fmt.Sprintf("\n%s Cost by runner\n", "\U0001F5A5"), "Runner", cs.Runners)
+}
+
+func costTable(w io.Writer, title, header string, bs []*parser.CostBreakdown) {
+	_, _ = fmt.Fprint(w, title)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "  %s\tJobs\tMinutes\tBillable\tCost\n", header)
+	for _, b := range bs {
+		name := b.Name
+		if b.Workflow != "" {
+			name = b.Workflow + " / " + b.Name
+		}
+		_, _ = fmt.Fprintf(tw, "  %s\t%d\t%.0f\t%.0f\t$%.2f\n", name, b.JobsCount, b.Minutes, b.BillableMinutes, b.Cost)
+	}
+	_ = tw.Flush()
+}
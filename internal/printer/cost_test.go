@@ -0,0 +1,50 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCost(t *testing.T) {
+	cs := &parser.CostSummary{
+		TotalRunsCount:  2,
+		JobsCount:       3,
+		Minutes:         7,
+		BillableMinutes: 52,
+		Cost:            0.416,
+		CostPerRun:      0.208,
+		Workflows: []*parser.CostBreakdown{
+			{Name: "CI", JobsCount: 3, Minutes: 7, BillableMinutes: 52, Cost: 0.416},
+		},
+		Jobs: []*parser.CostBreakdown{
+			{Name: "test", Workflow: "CI", JobsCount: 1, Minutes: 5, BillableMinutes: 50, Cost: 0.4},
+			{Name: "build", Workflow: "CI", JobsCount: 2, Minutes: 2, BillableMinutes: 2, Cost: 0.016},
+		},
+		Runners: []*parser.CostBreakdown{
+			{Name: "macos", JobsCount: 1, Minutes: 5, BillableMinutes: 50, Cost: 0.4},
+			{Name: "linux", JobsCount: 2, Minutes: 2, BillableMinutes: 2, Cost: 0.016},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	Cost(w, cs)
+
+	assert.Equal(t, "💰 Estimated cost: $0.42 (2 runs, $0.2080 per run)\n"+
+		"  Jobs: 3\n"+
+		"  Minutes: 7\n"+
+		"  Billable minutes: 52\n"+
+		"\n🗂 Cost by workflow\n"+
+		"  Workflow  Jobs  Minutes  Billable  Cost\n"+
+		"  CI        3     7        52        $0.42\n"+
+		"\n📊 Cost by job\n"+
+		"  Job         Jobs  Minutes  Billable  Cost\n"+
+		"  CI / test   1     5        50        $0.40\n"+
+		"  CI / build  2     2        2         $0.02\n"+
+		"\n🖥 Cost by runner\n"+
+		"  Runner  Jobs  Minutes  Billable  Cost\n"+
+		"  macos   1     5        50        $0.40\n"+
+		"  linux   2     2        2         $0.02\n", w.String())
+}
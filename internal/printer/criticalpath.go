@@ -0,0 +1,31 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+func CriticalPath(w io.Writer, cs *parser.CriticalPathSummary, n int) {
+	_, _ = fmt.Fprintf(w, "%s Critical path of %d successful runs\n", "\U0001F6E4", cs.RunsCount)
+	executionStats(w, cs.PathDurationStats)
+
+	jobsNum := min(len(cs.Jobs), n)
+	_, _ = fmt.Fprintf(w, "\n%s Top %d jobs on the critical path by time contributed\n", "\U0001F4CC", jobsNum)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  Job\tOn path (runs)\tShare\tAvg\tTotal")
+	for _, j := range cs.Jobs[:jobsNum] {
+		_, _ = fmt.Fprintf(tw, "  %s\t%d/%d (%.1f%%)\t%.1f%%\t%.1fs\t%.1fs\n",
+			j.Name,
+			j.CriticalRunsCount,
+			j.RunsCount,
+			j.CriticalRate*100,
+			j.Share*100,
+			j.TimeStats.Avg,
+			j.TotalTime,
+		)
+	}
+	_ = tw.Flush()
+}
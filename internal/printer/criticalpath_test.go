@@ -0,0 +1,33 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCriticalPath(t *testing.T) {
+	cs := &parser.CriticalPathSummary{
+		RunsCount:         2,
+		PathDurationStats: parser.ExecutionDurationStats{Min: 720, Max: 900, Avg: 810, Med: 810, Std: 90},
+		Jobs: []*parser.CriticalPathJob{
+			{Name: "test", RunsCount: 2, CriticalRunsCount: 1, CriticalRate: 0.5, TotalTime: 600, Share: 0.37, TimeStats: parser.ExecutionDurationStats{Avg: 600}},
+			{Name: "e2e", RunsCount: 2, CriticalRunsCount: 1, CriticalRate: 0.5, TotalTime: 540, Share: 0.33, TimeStats: parser.ExecutionDurationStats{Avg: 540}},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	CriticalPath(w, cs, 1)
+
+	assert.Equal(t, "🛤 Critical path of 2 successful runs\n"+
+		"  Min: 720.0s\n"+
+		"  Max: 900.0s\n"+
+		"  Avg: 810.0s\n"+
+		"  Med: 810.0s\n"+
+		"  Std: 90.0s\n"+
+		"\n📌 Top 1 jobs on the critical path by time contributed\n"+
+		"  Job   On path (runs)  Share  Avg     Total\n"+
+		"  test  1/2 (50.0%)     37.0%  600.0s  600.0s\n", w.String())
+}
@@ -0,0 +1,104 @@
+package printer
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+var (
+	runsHeader  = []string{"id", "run_attempt", "status", "conclusion", "actor", "created_at", "run_started_at", "updated_at", "duration", "html_url", "jobs_url", "logs_url"}
+	jobsHeader  = []string{"run_id", "run_attempt", "id", "name", "status", "conclusion", "runner_name", "started_at", "completed_at", "duration", "html_url"}
+	stepsHeader = []string{"run_id", "run_attempt", "job_id", "job_name", "number", "name", "status", "conclusion", "started_at", "completed_at", "duration"}
+)
+
+// RunsTable writes one record per workflow run. comma separates the fields, e.g. ',' for CSV or '\t' for TSV.
+func RunsTable(w io.Writer, runs []*parser.WorkflowRun, comma rune) error {
+	records := make([][]string, 0, len(runs))
+	for _, r := range runs {
+		records = append(records, []string{
+			strconv.FormatInt(r.ID, 10),
+			strconv.Itoa(r.RunAttempt),
+			r.Status,
+			r.Conclusion,
+			r.Actor,
+			formatTime(r.CreatedAt),
+			formatTime(r.RunStartedAt),
+			formatTime(r.UpdateAt),
+			formatDuration(r.Duration),
+			r.HTMLURL,
+			r.JobsURL,
+			r.LogsURL,
+		})
+	}
+	return writeTable(w, comma, runsHeader, records)
+}
+
+// JobsTable writes one record per job execution
+func JobsTable(w io.Writer, jobs []*parser.JobRow, comma rune) error {
+	records := make([][]string, 0, len(jobs))
+	for _, j := range jobs {
+		records = append(records, []string{
+			strconv.FormatInt(j.RunID, 10),
+			strconv.FormatInt(j.RunAttempt, 10),
+			strconv.FormatInt(j.ID, 10),
+			j.Name,
+			j.Status,
+			j.Conclusion,
+			j.RunnerName,
+			formatTime(j.StartedAt),
+			formatTime(j.CompletedAt),
+			formatDuration(j.Duration),
+			j.HTMLURL,
+		})
+	}
+	return writeTable(w, comma, jobsHeader, records)
+}
+
+// StepsTable writes one record per step execution
+func StepsTable(w io.Writer, steps []*parser.StepRow, comma rune) error {
+	records := make([][]string, 0, len(steps))
+	for _, s := range steps {
+		records = append(records, []string{
+			strconv.FormatInt(s.RunID, 10),
+			strconv.FormatInt(s.RunAttempt, 10),
+			strconv.FormatInt(s.JobID, 10),
+			s.JobName,
+			strconv.FormatInt(s.Number, 10),
+			s.Name,
+			s.Status,
+			s.Conclusion,
+			formatTime(s.StartedAt),
+			formatTime(s.CompletedAt),
+			formatDuration(s.Duration),
+		})
+	}
+	return writeTable(w, comma, stepsHeader, records)
+}
+
+func writeTable(w io.Writer, comma rune, header []string, records [][]string) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(records); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// formatTime formats t in RFC 3339. The zero time is written as an empty field.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatDuration(d float64) string {
+	return strconv.FormatFloat(d, 'f', -1, 64)
+}
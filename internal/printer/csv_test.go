@@ -0,0 +1,83 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunsTable(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	runs := []*parser.WorkflowRun{
+		{
+			ID:           1,
+			Status:       "completed",
+			Conclusion:   "success",
+			Actor:        "octocat",
+			RunAttempt:   1,
+			HTMLURL:      "https://github.com/owner/repo/actions/runs/1/attempts/1",
+			RunStartedAt: start,
+			UpdateAt:     start.Add(90 * time.Second),
+			CreatedAt:    start,
+			Duration:     90,
+		},
+	}
+
+	tests := []struct {
+		name  string
+		comma rune
+		want  string
+	}{
+		{
+			name:  "CSV",
+			comma: ',',
+			want: "id,run_attempt,status,conclusion,actor,created_at,run_started_at,updated_at,duration,html_url,jobs_url,logs_url\n" +
+				"1,1,completed,success,octocat,2024-01-01T00:00:00Z,2024-01-01T00:00:00Z,2024-01-01T00:01:30Z,90,https://github.com/owner/repo/actions/runs/1/attempts/1,,\n",
+		},
+		{
+			name:  "TSV",
+			comma: '\t',
+			want: "id\trun_attempt\tstatus\tconclusion\tactor\tcreated_at\trun_started_at\tupdated_at\tduration\thtml_url\tjobs_url\tlogs_url\n" +
+				"1\t1\tcompleted\tsuccess\toctocat\t2024-01-01T00:00:00Z\t2024-01-01T00:00:00Z\t2024-01-01T00:01:30Z\t90\thttps://github.com/owner/repo/actions/runs/1/attempts/1\t\t\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			require.NoError(t, RunsTable(w, runs, tt.comma))
+			assert.Equal(t, tt.want, w.String())
+		})
+	}
+}
+
+func TestJobsTable(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	jobs := []*parser.JobRow{
+		{RunID: 1, RunAttempt: 1, ID: 10, Name: "build, test", Status: "completed", Conclusion: "success", StartedAt: start, CompletedAt: start.Add(1500 * time.Millisecond), Duration: 1.5},
+		{RunID: 2, RunAttempt: 1, ID: 20, Name: "deploy", Status: "in_progress", StartedAt: start},
+	}
+
+	w := &bytes.Buffer{}
+	require.NoError(t, JobsTable(w, jobs, ','))
+
+	assert.Equal(t, "run_id,run_attempt,id,name,status,conclusion,runner_name,started_at,completed_at,duration,html_url\n"+
+		"1,1,10,\"build, test\",completed,success,,2024-01-01T00:00:00Z,2024-01-01T00:00:01Z,1.5,\n"+
+		"2,1,20,deploy,in_progress,,,2024-01-01T00:00:00Z,,0,\n", w.String())
+}
+
+func TestStepsTable(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	steps := []*parser.StepRow{
+		{RunID: 1, RunAttempt: 2, JobID: 10, JobName: "build", Number: 1, Name: "Run tests", Status: "completed", Conclusion: "failure", StartedAt: start, CompletedAt: start.Add(time.Minute), Duration: 60},
+	}
+
+	w := &bytes.Buffer{}
+	require.NoError(t, StepsTable(w, steps, '\t'))
+
+	assert.Equal(t, "run_id\trun_attempt\tjob_id\tjob_name\tnumber\tname\tstatus\tconclusion\tstarted_at\tcompleted_at\tduration\n"+
+		"1\t2\t10\tbuild\t1\tRun tests\tcompleted\tfailure\t2024-01-01T00:00:00Z\t2024-01-01T00:01:00Z\t60\n", w.String())
+}
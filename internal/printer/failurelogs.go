@@ -0,0 +1,46 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+func FailureLogs(w io.Writer, fs *parser.FailureLogsSummary, n int) {
+	_, _ = fmt.Fprintf(w, "\n%s Failure log clusters: %d failed jobs, %d logs\n", "\U0001F9FE", fs.FailuresCount, fs.LogsCount)
+	if len(fs.Clusters) == 0 {
+		_, _ = fmt.Fprintln(w, "  No error found in the logs of the failed jobs")
+		return
+	}
+
+	red := color.New(color.FgRed).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+	faint := color.New(color.Faint).SprintFunc()
+
+	for _, c := range fs.Clusters[:min(len(fs.Clusters), n)] {
+		_, _ = fmt.Fprintf(w, "  %s: %s\n", red(fmt.Sprintf("%d failures", c.Count)), c.Message)
+		_, _ = fmt.Fprintf(w, "    jobs: %s\n", cyan(strings.Join(c.Jobs, ", ")))
+		if len(c.Steps) > 0 {
+			_, _ = fmt.Fprintf(w, "    steps: %s\n", cyan(strings.Join(c.Steps, ", ")))
+		}
+		for _, l := range c.Excerpt {
+			_, _ = fmt.Fprintf(w, "    │ %s\n", faint(l))
+		}
+		for i, u := range c.HTMLURLs[:min(len(c.HTMLURLs), maxFailureLinks)] {
+			branch := "├──"
+			if i == min(len(c.HTMLURLs), maxFailureLinks)-1 && len(c.HTMLURLs) <= maxFailureLinks {
+				branch = "└──"
+			}
+			_, _ = fmt.Fprintf(w, "    %s%s\n", branch, u)
+		}
+		if len(c.HTMLURLs) > maxFailureLinks {
+			_, _ = fmt.Fprintf(w, "    └──and %d more\n", len(c.HTMLURLs)-maxFailureLinks)
+		}
+	}
+	if len(fs.Clusters) > n {
+		_, _ = fmt.Fprintf(w, "  and %d more clusters\n", len(fs.Clusters)-n)
+	}
+}
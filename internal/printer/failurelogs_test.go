@@ -0,0 +1,47 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailureLogs(t *testing.T) {
+	fs := &parser.FailureLogsSummary{
+		FailuresCount: 4,
+		LogsCount:     3,
+		Clusters: []*parser.FailureCluster{
+			{
+				Message:  "connection reset by peer",
+				Count:    2,
+				Jobs:     []string{"build", "release"},
+				Steps:    []string{"push image"},
+				Excerpt:  []string{"read tcp: connection reset by peer", "##[error]Process completed with exit code 1."},
+				HTMLURLs: []string{"https://example.com/1", "https://example.com/2"},
+			},
+			{Message: "Tests failed: N of N", Count: 1, Jobs: []string{"test"}, HTMLURLs: []string{"https://example.com/3"}},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	FailureLogs(w, fs, 1)
+
+	assert.Equal(t, "\n🧾 Failure log clusters: 4 failed jobs, 3 logs\n"+
+		"  2 failures: connection reset by peer\n"+
+		"    jobs: build, release\n"+
+		"    steps: push image\n"+
+		"    │ read tcp: connection reset by peer\n"+
+		"    │ ##[error]Process completed with exit code 1.\n"+
+		"    ├──https://example.com/1\n"+
+		"    └──https://example.com/2\n"+
+		"  and 1 more clusters\n", w.String())
+}
+
+func TestFailureLogs_Empty(t *testing.T) {
+	w := &bytes.Buffer{}
+	FailureLogs(w, &parser.FailureLogsSummary{FailuresCount: 1}, 3)
+
+	assert.Equal(t, "\n🧾 Failure log clusters: 1 failed jobs, 0 logs\n  No error found in the logs of the failed jobs\n", w.String())
+}
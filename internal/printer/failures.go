@@ -0,0 +1,40 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+const seenDateFormat = "2006-01-02"
+
+func FailureSignatures(w io.Writer, fs *parser.FailureSignaturesSummary, n int) {
+	_, _ = fmt.Fprintf(w, "%s Failures: %d, signatures: %d\n", "\U0001F9E9", fs.FailuresCount, len(fs.Signatures))
+	if len(fs.Signatures) == 0 {
+		return
+	}
+
+	signaturesNum := min(len(fs.Signatures), n)
+	_, _ = fmt.Fprintf(w, "\n%s Top %d failure signatures (job / step / annotation)\n", "\U0001F4C8", signaturesNum)
+
+	red := color.New(color.FgRed).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	for _, s := range fs.Signatures[:signaturesNum] {
+		_, _ = fmt.Fprintf(w, "  %s: %s\n", red(fmt.Sprintf("%d failures", s.Count)), cyan(s.Signature))
+		_, _ = fmt.Fprintf(w, "    first seen: %s, last seen: %s\n", s.FirstSeen.Format(seenDateFormat), s.LastSeen.Format(seenDateFormat))
+		for i, u := range s.HTMLURLs[:min(len(s.HTMLURLs), maxFailureLinks)] {
+			branch := "├──"
+			if i == min(len(s.HTMLURLs), maxFailureLinks)-1 && len(s.HTMLURLs) <= maxFailureLinks {
+				branch = "└──"
+			}
+			_, _ = fmt.Fprintf(w, "    %s%s\n", branch, u)
+		}
+		if len(s.HTMLURLs) > maxFailureLinks {
+			_, _ = fmt.Fprintf(w, "    └──and %d more\n", len(s.HTMLURLs)-maxFailureLinks)
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+}
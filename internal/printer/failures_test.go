@@ -0,0 +1,41 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailureSignatures(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 12, 0, 0, 0, time.UTC) }
+	fs := &parser.FailureSignaturesSummary{
+		FailuresCount: 9,
+		Signatures: []*parser.FailureSignature{
+			{
+				Signature: "test / run tests / TestFoo timed out after Ns",
+				Count:     7,
+				FirstSeen: day(1),
+				LastSeen:  day(3),
+				HTMLURLs:  []string{"https://example.com/1", "https://example.com/2", "https://example.com/3", "https://example.com/4", "https://example.com/5", "https://example.com/6", "https://example.com/7"},
+			},
+			{Signature: "lint", Count: 2, FirstSeen: day(2), LastSeen: day(2), HTMLURLs: []string{"https://example.com/8", "https://example.com/9"}},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	FailureSignatures(w, fs, 1)
+
+	assert.Equal(t, "🧩 Failures: 9, signatures: 2\n"+
+		"\n📈 Top 1 failure signatures (job / step / annotation)\n"+
+		"  7 failures: test / run tests / TestFoo timed out after Ns\n"+
+		"    first seen: 2024-01-01, last seen: 2024-01-03\n"+
+		"    ├──https://example.com/1\n"+
+		"    ├──https://example.com/2\n"+
+		"    ├──https://example.com/3\n"+
+		"    ├──https://example.com/4\n"+
+		"    ├──https://example.com/5\n"+
+		"    └──and 2 more\n\n", w.String())
+}
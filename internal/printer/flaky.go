@@ -0,0 +1,42 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+func Flaky(w io.Writer, fs *parser.FlakySummary, n int) {
+	_, _ = fmt.Fprintf(w, "%s Flaky runs: %d/%d (%d runs retried)\n", "\U0001F3B2", fs.FlakyRunsCount, fs.RunsCount, fs.RetriedRunsCount)
+	if len(fs.Jobs) == 0 {
+		_, _ = fmt.Fprintln(w, "  No job failed on an attempt and succeeded on a retry")
+		return
+	}
+
+	jobsNum := min(len(fs.Jobs), n)
+	_, _ = fmt.Fprintf(w, "\n%s Top %d flaky jobs (flaky runs / total runs)\n", "\U0001F4C8", jobsNum)
+
+	red := color.New(color.FgRed).SprintFunc()
+	purple := color.New(color.FgHiMagenta).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	for _, job := range fs.Jobs[:jobsNum] {
+		_, _ = fmt.Fprintf(w, "  %s: %s\n", cyan(job.Name), red(fmt.Sprintf("%d/%d (%.1f%%)", job.FlakyRunsCount, job.RunsCount, job.FlakeRate*100)))
+		for _, step := range job.Steps {
+			_, _ = fmt.Fprintf(w, "    ├──%s: %s\n", purple(step.Name), red(fmt.Sprintf("%d/%d (%.1f%%)", step.FlakyRunsCount, step.RunsCount, step.FlakeRate*100)))
+		}
+		for i, a := range job.Attempts[:min(len(job.Attempts), maxFailureLinks)] {
+			branch := "├──"
+			if i == min(len(job.Attempts), maxFailureLinks)-1 && len(job.Attempts) <= maxFailureLinks {
+				branch = "└──"
+			}
+			_, _ = fmt.Fprintf(w, "    %s%s\n", branch, a.HTMLURL)
+		}
+		if len(job.Attempts) > maxFailureLinks {
+			_, _ = fmt.Fprintf(w, "    └──and %d more\n", len(job.Attempts)-maxFailureLinks)
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+}
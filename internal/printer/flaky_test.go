@@ -0,0 +1,59 @@
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlaky(t *testing.T) {
+	attempts := make([]*parser.FlakyAttempt, 0, 6)
+	for i := 1; i <= 6; i++ {
+		attempts = append(attempts, &parser.FlakyAttempt{RunID: int64(i), RunAttempt: 1, HTMLURL: fmt.Sprintf("https://example.com/job/%d", i)})
+	}
+	fs := &parser.FlakySummary{
+		RunsCount:        10,
+		RetriedRunsCount: 7,
+		FlakyRunsCount:   6,
+		Jobs: []*parser.FlakyJob{
+			{
+				Name: "test", RunsCount: 10, FlakyRunsCount: 6, FlakeRate: 0.6,
+				Steps:    []*parser.FlakyStep{{Name: "run tests", Number: 2, RunsCount: 10, FlakyRunsCount: 6, FlakeRate: 0.6}},
+				Attempts: attempts,
+			},
+			{
+				Name: "lint", RunsCount: 10, FlakyRunsCount: 1, FlakeRate: 0.1,
+				Steps:    []*parser.FlakyStep{},
+				Attempts: attempts[:1],
+			},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	Flaky(w, fs, 3)
+
+	assert.Equal(t, "🎲 Flaky runs: 6/10 (7 runs retried)\n"+
+		"\n📈 Top 2 flaky jobs (flaky runs / total runs)\n"+
+		"  test: 6/10 (60.0%)\n"+
+		"    ├──run tests: 6/10 (60.0%)\n"+
+		"    ├──https://example.com/job/1\n"+
+		"    ├──https://example.com/job/2\n"+
+		"    ├──https://example.com/job/3\n"+
+		"    ├──https://example.com/job/4\n"+
+		"    ├──https://example.com/job/5\n"+
+		"    └──and 1 more\n"+
+		"\n"+
+		"  lint: 1/10 (10.0%)\n"+
+		"    └──https://example.com/job/1\n"+
+		"\n", w.String())
+}
+
+func TestFlaky_NoFlakes(t *testing.T) {
+	w := &bytes.Buffer{}
+	Flaky(w, &parser.FlakySummary{RunsCount: 3, Jobs: []*parser.FlakyJob{}}, 3)
+
+	assert.Equal(t, "🎲 Flaky runs: 0/3 (0 runs retried)\n  No job failed on an attempt and succeeded on a retry\n", w.String())
+}
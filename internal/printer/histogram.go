@@ -0,0 +1,94 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+// histogramBarWidth is the number of characters of the longest histogram bar
+const histogramBarWidth = 40
+
+// sparklineLevels are the characters of the sparkline bars, from the lowest to the highest
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+// durationHistogram prints the duration histogram of the successful runs and a hint when it looks bimodal
+func durationHistogram(w io.Writer, h *parser.DurationHistogram) {
+	_, _ = fmt.Fprintf(w, "\n%s Workflow run duration distribution\n", "\U0001F4F6")
+	if len(h.Buckets) == 0 {
+		_, _ = fmt.Fprintln(w, "  No successful runs")
+		return
+	}
+
+	maxCount := 0
+	for _, b := range h.Buckets {
+		maxCount = max(maxCount, b.Count)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, b := range h.Buckets {
+		width := b.Count * histogramBarWidth / maxCount
+		if b.Count > 0 {
+			width = max(width, 1)
+		}
+		_, _ = fmt.Fprintf(tw, "  %.1fs - %.1fs\t%s\t%d\n", b.Lower, b.Upper, strings.Repeat("█", width), b.Count)
+	}
+	_ = tw.Flush()
+	if h.Bimodal {
+		_, _ = fmt.Fprintf(w, "  %s %s, the average is misleading\n", color.New(color.FgYellow).Sprint("⚠"), bimodalHint(h))
+	}
+}
+
+// JobDurationHistograms prints a sparkline of the durations of the n jobs with the longest average duration
+func JobDurationHistograms(w io.Writer, jobs []*parser.WorkflowJobsStatsSummary, n int) {
+	sorted := make([]*parser.WorkflowJobsStatsSummary, 0, len(jobs))
+	for _, j := range jobs {
+		if j.DurationHistogram != nil && len(j.DurationHistogram.Buckets) > 0 {
+			sorted = append(sorted, j)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ExecutionDurationStats.Avg > sorted[j].ExecutionDurationStats.Avg
+	})
+	jobsNum := min(len(sorted), n)
+	_, _ = fmt.Fprintf(w, "\n%s Duration distribution of the top %d jobs with the longest execution average duration\n", "\U0001F4F6", jobsNum)
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, j := range sorted[:jobsNum] {
+		h := j.DurationHistogram
+		hint := ""
+		if h.Bimodal {
+			hint = yellow(bimodalHint(h))
+		}
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\t%.1fs - %.1fs\t%s\n", cyan(j.Name), sparkline(h.Buckets), h.Buckets[0].Lower, h.Buckets[len(h.Buckets)-1].Upper, hint)
+	}
+	_ = tw.Flush()
+}
+
+// sparkline draws a bar per bucket scaled to the highest bucket. Empty buckets are blank.
+func sparkline(buckets []parser.HistogramBucket) string {
+	maxCount := 0
+	for _, b := range buckets {
+		maxCount = max(maxCount, b.Count)
+	}
+	var sb strings.Builder
+	for _, b := range buckets {
+		if b.Count == 0 {
+			sb.WriteRune(' ')
+			continue
+		}
+		sb.WriteRune(sparklineLevels[(b.Count*len(sparklineLevels)-1)/maxCount])
+	}
+	return sb.String()
+}
+
+func bimodalHint(h *parser.DurationHistogram) string {
+	return fmt.Sprintf("Bimodal (bimodality coefficient %.2f): modes around %.1fs and %.1fs", h.BimodalityCoefficient, h.Modes[0], h.Modes[1])
+}
@@ -0,0 +1,96 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationHistogram(t *testing.T) {
+	tests := []struct {
+		name string
+		h    *parser.DurationHistogram
+		want string
+	}{
+		{
+			name: "Bimodal",
+			h: &parser.DurationHistogram{
+				Buckets: []parser.HistogramBucket{
+					{Lower: 60, Upper: 120, Count: 8},
+					{Lower: 120, Upper: 180, Count: 1},
+					{Lower: 180, Upper: 240, Count: 0},
+					{Lower: 240, Upper: 300, Count: 4},
+				},
+				BimodalityCoefficient: 0.72,
+				Bimodal:               true,
+				Modes:                 []float64{90, 270},
+			},
+			want: "\n📶 Workflow run duration distribution\n" +
+				"  60.0s - 120.0s   ████████████████████████████████████████  8\n" +
+				"  120.0s - 180.0s  █████                                     1\n" +
+				"  180.0s - 240.0s                                            0\n" +
+				"  240.0s - 300.0s  ████████████████████                      4\n" +
+				"  ⚠ Bimodal (bimodality coefficient 0.72): modes around 90.0s and 270.0s, the average is misleading\n",
+		},
+		{
+			name: "No successful runs",
+			h:    &parser.DurationHistogram{Buckets: []parser.HistogramBucket{}},
+			want: "\n📶 Workflow run duration distribution\n  No successful runs\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			durationHistogram(w, tt.h)
+			assert.Equal(t, tt.want, w.String())
+		})
+	}
+}
+
+func TestJobDurationHistograms(t *testing.T) {
+	jobs := []*parser.WorkflowJobsStatsSummary{
+		{
+			Name:                   "test",
+			ExecutionDurationStats: parser.ExecutionDurationStats{Avg: 200},
+			DurationHistogram: &parser.DurationHistogram{
+				Buckets: []parser.HistogramBucket{
+					{Lower: 60, Upper: 150, Count: 8},
+					{Lower: 150, Upper: 240, Count: 0},
+					{Lower: 240, Upper: 330, Count: 6},
+				},
+				BimodalityCoefficient: 0.81,
+				Bimodal:               true,
+				Modes:                 []float64{105, 285},
+			},
+		},
+		{
+			Name:                   "build",
+			ExecutionDurationStats: parser.ExecutionDurationStats{Avg: 60},
+			DurationHistogram: &parser.DurationHistogram{
+				Buckets: []parser.HistogramBucket{
+					{Lower: 50, Upper: 60, Count: 1},
+					{Lower: 60, Upper: 70, Count: 4},
+					{Lower: 70, Upper: 80, Count: 2},
+				},
+			},
+		},
+		{Name: "lint", ExecutionDurationStats: parser.ExecutionDurationStats{Avg: 500}, DurationHistogram: &parser.DurationHistogram{Buckets: []parser.HistogramBucket{}}},
+		{Name: "deploy", ExecutionDurationStats: parser.ExecutionDurationStats{Avg: 900}},
+	}
+
+	w := &bytes.Buffer{}
+	JobDurationHistograms(w, jobs, 3)
+
+	assert.Equal(t, "\n📶 Duration distribution of the top 2 jobs with the longest execution average duration\n"+
+		"  test   █ ▆  60.0s - 330.0s  Bimodal (bimodality coefficient 0.81): modes around 105.0s and 285.0s\n"+
+		"  build  ▂█▄  50.0s - 80.0s   \n", w.String())
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▂▃▄▅▆▇█ ", sparkline([]parser.HistogramBucket{
+		{Count: 1}, {Count: 2}, {Count: 3}, {Count: 4}, {Count: 5}, {Count: 6}, {Count: 7}, {Count: 8}, {Count: 0},
+	}))
+	assert.Equal(t, "", sparkline(nil))
+}
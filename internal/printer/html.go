@@ -0,0 +1,258 @@
+package printer
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+//go:embed templates/report.html.tmpl
+var reportTemplate string
+
+var reportTmpl = template.Must(template.New("report").Funcs(template.FuncMap{
+	"pct": func(rate float64) string { return fmt.Sprintf("%.1f%%", rate*100) },
+	"sec": func(d float64) string { return fmt.Sprintf("%.1fs", d) },
+	"inc": func(i int) int { return i + 1 },
+}).Parse(reportTemplate))
+
+// HTMLReport is the data of the HTML report
+type HTMLReport struct {
+	Title       string
+	GeneratedAt time.Time
+	RateLimited bool
+	Runs        *parser.WorkflowRunsStatsSummary
+	Jobs        []*parser.WorkflowJobsStatsSummary
+	Trend       *parser.WorkflowRunsTrendSummary
+	Histogram   []parser.HistogramBucket
+}
+
+// chart is the geometry of the SVG charts
+type chart struct {
+	Width, Height, TrendWidth            int
+	Left, Right, TrendRight, Top, Bottom float64
+}
+
+var reportChart = chart{
+	Width:      480,
+	Height:     220,
+	TrendWidth: 1000,
+	Left:       10,
+	Right:      470,
+	TrendRight: 990,
+	Top:        10,
+	Bottom:     190,
+}
+
+type pieSlice struct {
+	Label  string
+	Color  string
+	Count  int
+	Rate   float64
+	Path   string
+	Circle bool
+}
+
+type histogramBar struct {
+	X, Y, Width, Height string
+	Lower, Upper        float64
+	Count               int
+}
+
+type histogramAxis struct {
+	Min, Max      string
+	MinX, MaxX, Y float64
+}
+
+type trendPoint struct {
+	X, SuccessY, MedY string
+	Label             string
+	Runs              int
+	SuccessRate       float64
+	Med               float64
+}
+
+type trendLine struct {
+	Points        []trendPoint
+	SuccessPoints string
+	MedPoints     string
+	MaxMed        float64
+	First, Last   string
+	LabelY        float64
+}
+
+type htmlView struct {
+	Title         string
+	GeneratedAt   string
+	RateLimited   bool
+	Runs          *parser.WorkflowRunsStatsSummary
+	Jobs          []*parser.WorkflowJobsStatsSummary
+	Chart         chart
+	Pie           []pieSlice
+	Histogram     []histogramBar
+	HistogramAxis *histogramAxis
+	Trend         *trendLine
+	Percentiles   []string
+}
+
+// HTML writes a self-contained HTML report. It does not load any external resource.
+func HTML(w io.Writer, r HTMLReport) error {
+	jobs := make([]*parser.WorkflowJobsStatsSummary, len(r.Jobs))
+	copy(jobs, r.Jobs)
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Name < jobs[j].Name
+	})
+
+	v := htmlView{
+		Title:       r.Title,
+		GeneratedAt: r.GeneratedAt.UTC().Format(time.RFC3339),
+		RateLimited: r.RateLimited,
+		Runs:        r.Runs,
+		Jobs:        jobs,
+		Chart:       reportChart,
+		Pie:         pieSlices(r.Runs),
+		Histogram:   histogramBars(r.Histogram),
+		Trend:       newTrendLine(r.Trend),
+	}
+	// The jobs and steps have the same percentiles as the runs
+	if r.Runs != nil {
+		for _, p := range r.Runs.ExecutionDurationStats.Percentiles {
+			v.Percentiles = append(v.Percentiles, strings.ToUpper(p.Label()))
+		}
+	}
+	if len(r.Histogram) > 0 {
+		v.HistogramAxis = &histogramAxis{
+			Min:  fmt.Sprintf("%.1fs", r.Histogram[0].Lower),
+			Max:  fmt.Sprintf("%.1fs", r.Histogram[len(r.Histogram)-1].Upper),
+			MinX: reportChart.Left,
+			MaxX: reportChart.Right,
+			Y:    reportChart.Bottom + 15,
+		}
+	}
+	return reportTmpl.Execute(w, v)
+}
+
+// pieSlices returns the slices of the conclusions pie chart. Conclusions without runs are omitted.
+func pieSlices(wrs *parser.WorkflowRunsStatsSummary) []pieSlice {
+	const cx, cy, r = 110.0, 110.0, 100.0
+	if wrs == nil || wrs.TotalRunsCount == 0 {
+		return nil
+	}
+
+	conclusions := []struct {
+		key, label, color string
+	}{
+		{parser.ConclusionSuccess, "Success", "#2da44e"},
+		{parser.ConclusionFailure, "Failure", "#cf222e"},
+		{parser.ConclusionOthers, "Others", "#d4a72c"},
+	}
+	pie := []pieSlice{}
+	angle := -math.Pi / 2
+	for _, c := range conclusions {
+		wrc, ok := wrs.Conclusions[c.key]
+		if !ok || wrc.RunsCount == 0 {
+			continue
+		}
+		rate := float64(wrc.RunsCount) / float64(wrs.TotalRunsCount)
+		s := pieSlice{Label: c.label, Color: c.color, Count: wrc.RunsCount, Rate: rate}
+		if wrc.RunsCount == wrs.TotalRunsCount {
+			s.Circle = true
+			pie = append(pie, s)
+			continue
+		}
+		end := angle + rate*2*math.Pi
+		large := 0
+		if rate > 0.5 {
+			large = 1
+		}
+		s.Path = fmt.Sprintf("M %.2f %.2f L %.2f %.2f A %.2f %.2f 0 %d 1 %.2f %.2f Z",
+			cx, cy,
+			cx+r*math.Cos(angle), cy+r*math.Sin(angle),
+			r, r, large,
+			cx+r*math.Cos(end), cy+r*math.Sin(end),
+		)
+		pie = append(pie, s)
+		angle = end
+	}
+	return pie
+}
+
+func histogramBars(h []parser.HistogramBucket) []histogramBar {
+	if len(h) == 0 {
+		return nil
+	}
+	maxCount := 0
+	for _, b := range h {
+		maxCount = max(maxCount, b.Count)
+	}
+
+	c := reportChart
+	width := (c.Right - c.Left) / float64(len(h))
+	bars := make([]histogramBar, 0, len(h))
+	for i, b := range h {
+		height := (c.Bottom - c.Top) * float64(b.Count) / float64(max(maxCount, 1))
+		bars = append(bars, histogramBar{
+			X:      fmt.Sprintf("%.2f", c.Left+width*float64(i)+1),
+			Y:      fmt.Sprintf("%.2f", c.Bottom-height),
+			Width:  fmt.Sprintf("%.2f", max(width-2, 1)),
+			Height: fmt.Sprintf("%.2f", height),
+			Lower:  b.Lower,
+			Upper:  b.Upper,
+			Count:  b.Count,
+		})
+	}
+	return bars
+}
+
+// newTrendLine plots the success rate and the median duration of every bucket.
+// The success rate is scaled to 0-100% and the median duration to 0 to the largest median.
+func newTrendLine(ts *parser.WorkflowRunsTrendSummary) *trendLine {
+	if ts == nil || len(ts.Buckets) == 0 {
+		return nil
+	}
+	c := reportChart
+	maxMed := 0.0
+	for _, b := range ts.Buckets {
+		maxMed = max(maxMed, b.ExecutionDurationStats.Med)
+	}
+
+	step := 0.0
+	if len(ts.Buckets) > 1 {
+		step = (c.TrendRight - c.Left) / float64(len(ts.Buckets)-1)
+	}
+	t := &trendLine{
+		Points: make([]trendPoint, 0, len(ts.Buckets)),
+		MaxMed: maxMed,
+		First:  ts.Buckets[0].Start.Format("2006-01-02"),
+		Last:   ts.Buckets[len(ts.Buckets)-1].Start.Format("2006-01-02"),
+		LabelY: c.Bottom + 15,
+	}
+	success := make([]string, 0, len(ts.Buckets))
+	med := make([]string, 0, len(ts.Buckets))
+	for i, b := range ts.Buckets {
+		x := c.Left + step*float64(i)
+		sy := c.Bottom - (c.Bottom-c.Top)*b.Rate.SuccesRate
+		my := c.Bottom - (c.Bottom-c.Top)*b.ExecutionDurationStats.Med/max(maxMed, 1)
+		p := trendPoint{
+			X:           fmt.Sprintf("%.2f", x),
+			SuccessY:    fmt.Sprintf("%.2f", sy),
+			MedY:        fmt.Sprintf("%.2f", my),
+			Label:       b.Start.Format("2006-01-02"),
+			Runs:        b.TotalRunsCount,
+			SuccessRate: b.Rate.SuccesRate,
+			Med:         b.ExecutionDurationStats.Med,
+		}
+		t.Points = append(t.Points, p)
+		success = append(success, p.X+","+p.SuccessY)
+		med = append(med, p.X+","+p.MedY)
+	}
+	t.SuccessPoints = strings.Join(success, " ")
+	t.MedPoints = strings.Join(med, " ")
+	return t
+}
@@ -0,0 +1,95 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTML(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := HTMLReport{
+		Title:       "owner/repo <ci.yaml>",
+		GeneratedAt: start,
+		RateLimited: true,
+		Runs: &parser.WorkflowRunsStatsSummary{
+			TotalRunsCount: 4,
+			Name:           "CI",
+			Rate:           parser.Rate{SuccesRate: 0.75, FailureRate: 0.25},
+			Conclusions: map[string]*parser.WorkflowRunsConclusion{
+				parser.ConclusionSuccess: {RunsCount: 3},
+				parser.ConclusionFailure: {RunsCount: 1},
+				parser.ConclusionOthers:  {RunsCount: 0},
+			},
+		},
+		Jobs: []*parser.WorkflowJobsStatsSummary{
+			{Name: "test", TotalRunsCount: 4, StepSummary: []*parser.StepSummary{
+				{Name: "go test", Number: 2, RunsCount: 4, FailureHTMLURL: []string{"https://github.com/owner/repo/actions/runs/1/job/2"}},
+			}},
+			{Name: "build", TotalRunsCount: 4},
+		},
+		Trend: &parser.WorkflowRunsTrendSummary{
+			Buckets: []*parser.TrendBucket{
+				{Start: start, TotalRunsCount: 2, Rate: parser.Rate{SuccesRate: 1}, ExecutionDurationStats: parser.TrendDurationStats{Med: 10}},
+				{Start: start.AddDate(0, 0, 1), TotalRunsCount: 2, Rate: parser.Rate{SuccesRate: 0.5}, ExecutionDurationStats: parser.TrendDurationStats{Med: 20}},
+			},
+		},
+		Histogram: []parser.HistogramBucket{{Lower: 10, Upper: 15, Count: 1}, {Lower: 15, Upper: 20, Count: 2}},
+	}
+
+	w := &bytes.Buffer{}
+	require.NoError(t, HTML(w, r))
+	got := w.String()
+
+	assert.Contains(t, got, "<title>owner/repo &lt;ci.yaml&gt;</title>")
+	assert.Contains(t, got, "Generated at 2024-01-01T00:00:00Z")
+	assert.Contains(t, got, "rate limit")
+	assert.Contains(t, got, "<title>Success: 3 (75.0%)</title>")
+	assert.Contains(t, got, "<title>Failure: 1 (25.0%)</title>")
+	assert.NotContains(t, got, "Others 0")
+	assert.Contains(t, got, `<title>15.0s - 20.0s: 2 runs</title>`)
+	assert.Contains(t, got, `<polyline points="10.00,10.00 990.00,100.00"`)
+	assert.Contains(t, got, `<polyline points="10.00,100.00 990.00,10.00"`)
+	assert.Contains(t, got, `<a href="https://github.com/owner/repo/actions/runs/1/job/2">#1</a>`)
+	assert.Less(t, strings.Index(got, "<td>build</td>"), strings.Index(got, "<td>test</td>"), "jobs are sorted by name")
+	assert.NotContains(t, got, "http://", "no external resources")
+	assert.NotContains(t, got, "<script src")
+	assert.NotContains(t, got, "<link")
+}
+
+func TestHTML_Empty(t *testing.T) {
+	w := &bytes.Buffer{}
+	require.NoError(t, HTML(w, HTMLReport{
+		Title: "empty",
+		Runs:  parser.WorkflowRunsParse(nil),
+	}))
+
+	got := w.String()
+	assert.Contains(t, got, "<title>No runs</title>")
+	assert.Contains(t, got, "No runs.")
+	assert.NotContains(t, got, "<h2>Jobs</h2>")
+}
+
+func TestPieSlices(t *testing.T) {
+	wrs := &parser.WorkflowRunsStatsSummary{
+		TotalRunsCount: 2,
+		Conclusions: map[string]*parser.WorkflowRunsConclusion{
+			parser.ConclusionSuccess: {RunsCount: 2},
+		},
+	}
+	got := pieSlices(wrs)
+	require.Len(t, got, 1)
+	assert.True(t, got[0].Circle)
+
+	wrs.Conclusions[parser.ConclusionFailure] = &parser.WorkflowRunsConclusion{RunsCount: 2}
+	wrs.TotalRunsCount = 4
+	got = pieSlices(wrs)
+	require.Len(t, got, 2)
+	assert.Equal(t, "M 110.00 110.00 L 110.00 10.00 A 100.00 100.00 0 0 1 110.00 210.00 Z", got[0].Path)
+	assert.Equal(t, "M 110.00 110.00 L 110.00 210.00 A 100.00 100.00 0 0 1 110.00 10.00 Z", got[1].Path)
+}
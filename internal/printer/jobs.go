@@ -59,6 +59,10 @@ func LongestDurationJobs(w io.Writer, jobs []*parser.WorkflowJobsStatsSummary, n
 
 	for i := 0; i < jobsNum; i++ {
 		job := jobs[i]
-		_, _ = fmt.Fprintf(w, "  %s: %s\n", cyan(job.Name), red(fmt.Sprintf("%.2fs", job.ExecutionDurationStats.Avg)))
+		if len(job.ExecutionDurationStats.Percentiles) == 0 {
+			_, _ = fmt.Fprintf(w, "  %s: %s\n", cyan(job.Name), red(fmt.Sprintf("%.2fs", job.ExecutionDurationStats.Avg)))
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "  %s: %s (%s)\n", cyan(job.Name), red(fmt.Sprintf("%.2fs", job.ExecutionDurationStats.Avg)), formatPercentiles(job.ExecutionDurationStats.Percentiles))
 	}
 }
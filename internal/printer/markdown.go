@@ -0,0 +1,192 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+// maxFailureLinks is the number of failed job links listed for the worst step of a job
+const maxFailureLinks = 5
+
+func RunsMarkdown(w io.Writer, wrs *parser.WorkflowRunsStatsSummary) {
+	var sc, fc, oc int
+	if c, ok := wrs.Conclusions[parser.ConclusionSuccess]; ok {
+		sc = c.RunsCount
+	}
+	if c, ok := wrs.Conclusions[parser.ConclusionFailure]; ok {
+		fc = c.RunsCount
+	}
+	if c, ok := wrs.Conclusions[parser.ConclusionOthers]; ok {
+		oc = c.RunsCount
+	}
+
+	title := "Workflow runs"
+	if wrs.Name != "" {
+		title = fmt.Sprintf("%s workflow runs", escapeMarkdown(wrs.Name))
+	}
+	_, _ = fmt.Fprintf(w, "### %s %s\n\n", "\U0001F3C3", title)
+	_, _ = fmt.Fprintf(w, "Total runs: **%d**\n\n", wrs.TotalRunsCount)
+	_, _ = fmt.Fprintln(w, "| Conclusion | Runs | Rate |")
+	_, _ = fmt.Fprintln(w, "| --- | ---: | ---: |")
+	_, _ = fmt.Fprintf(w, "| ✔ Success | %d | %.1f%% |\n", sc, wrs.Rate.SuccesRate*100)
+	_, _ = fmt.Fprintf(w, "| ✖ Failure | %d | %.1f%% |\n", fc, wrs.Rate.FailureRate*100)
+	_, _ = fmt.Fprintf(w, "| \U0001F914 Others | %d | %.1f%% |\n", oc, wrs.Rate.OthersRate*100)
+
+	if ar := wrs.AttemptRates; ar != nil {
+		_, _ = fmt.Fprintf(w, "\n### %s Success rate by attempt\n\n", "\U0001F501")
+		_, _ = fmt.Fprintf(w, "%d runs, %d attempts\n\n", ar.RunsCount, ar.AttemptsCount)
+		_, _ = fmt.Fprintln(w, "| First attempt | Final | Per attempt |")
+		_, _ = fmt.Fprintln(w, "| ---: | ---: | ---: |")
+		_, _ = fmt.Fprintf(w, "| %.1f%% | %.1f%% | %.1f%% |\n", ar.FirstAttemptSuccessRate*100, ar.FinalSuccessRate*100, ar.PerAttemptSuccessRate*100)
+	}
+
+	_, _ = fmt.Fprintf(w, "\n### %s Workflow run execution time stats\n\n", "⏰")
+	executionStatsMarkdown(w, wrs.ExecutionDurationStats)
+
+	if wrs.JobTimeStats != nil {
+		_, _ = fmt.Fprintf(w, "\n### %s Sum of job execution time stats\n\n", "⚙")
+		executionStatsMarkdown(w, *wrs.JobTimeStats)
+	}
+}
+
+func executionStatsMarkdown(w io.Writer, s parser.ExecutionDurationStats) {
+	ps := s.Percentiles
+	_, _ = fmt.Fprintf(w, "| Min | Max | Avg | Med | Std |%s\n", percentileHeaders(ps))
+	_, _ = fmt.Fprintf(w, "| ---: | ---: | ---: | ---: | ---: |%s\n", strings.Repeat(" ---: |", len(ps)))
+	_, _ = fmt.Fprintf(w, "| %.1fs | %.1fs | %.1fs | %.1fs | %.1fs |%s\n",
+		s.Min,
+		s.Max,
+		s.Avg,
+		s.Med,
+		s.Std,
+		percentileCells(ps, "%.1fs"),
+	)
+}
+
+func FailureJobsMarkdown(w io.Writer, jobs []*parser.WorkflowJobsStatsSummary, n int) {
+	sorted := sortedJobs(jobs, func(a, b *parser.WorkflowJobsStatsSummary) bool {
+		return a.Conclusions[parser.ConclusionFailure] > b.Conclusions[parser.ConclusionFailure]
+	})
+	jobsNum := min(len(sorted), n)
+	_, _ = fmt.Fprintf(w, "\n### %s Top %d jobs with the highest failure counts\n\n", "\U0001F4C8", jobsNum)
+	_, _ = fmt.Fprintln(w, "| Job | Failures | Worst step | Step failures | Failed jobs |")
+	_, _ = fmt.Fprintln(w, "| --- | ---: | --- | ---: | --- |")
+
+	for _, job := range sorted[:jobsNum] {
+		worst := worstStep(job)
+		stepName, stepFailures, links := "-", "-", "-"
+		if worst != nil {
+			stepName = escapeMarkdown(worst.Name)
+			stepFailures = fmt.Sprintf("%d/%d", worst.Conclusions[parser.ConclusionFailure], worst.RunsCount)
+			links = failureLinks(worst.FailureHTMLURL)
+		}
+		_, _ = fmt.Fprintf(w, "| %s | %d/%d | %s | %s | %s |\n",
+			escapeMarkdown(job.Name),
+			job.Conclusions[parser.ConclusionFailure],
+			job.TotalRunsCount,
+			stepName,
+			stepFailures,
+			links,
+		)
+	}
+}
+
+func LongestDurationJobsMarkdown(w io.Writer, jobs []*parser.WorkflowJobsStatsSummary, n int) {
+	sorted := sortedJobs(jobs, func(a, b *parser.WorkflowJobsStatsSummary) bool {
+		return a.ExecutionDurationStats.Avg > b.ExecutionDurationStats.Avg
+	})
+	jobsNum := min(len(sorted), n)
+	_, _ = fmt.Fprintf(w, "\n### %s Top %d jobs with the longest execution average duration\n\n", "\U0001F4CA", jobsNum)
+	// Every job has the same percentiles
+	var ps []parser.Percentile
+	if len(sorted) > 0 {
+		ps = sorted[0].ExecutionDurationStats.Percentiles
+	}
+	_, _ = fmt.Fprintf(w, "| Job | Avg | Med | Max |%s\n", percentileHeaders(ps))
+	_, _ = fmt.Fprintf(w, "| --- | ---: | ---: | ---: |%s\n", strings.Repeat(" ---: |", len(ps)))
+
+	for _, job := range sorted[:jobsNum] {
+		_, _ = fmt.Fprintf(w, "| %s | %.2fs | %.2fs | %.2fs |%s\n",
+			escapeMarkdown(job.Name),
+			job.ExecutionDurationStats.Avg,
+			job.ExecutionDurationStats.Med,
+			job.ExecutionDurationStats.Max,
+			percentileCells(job.ExecutionDurationStats.Percentiles, "%.2fs"),
+		)
+	}
+}
+
+func RateLimitWarningMarkdown(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "> [!WARNING]\n> You have reached the rate limit for the GitHub API. These results may not be accurate.\n\n")
+}
+
+// sortedJobs returns a copy of jobs sorted by less. Ties are broken by name so that the output is stable.
+func sortedJobs(jobs []*parser.WorkflowJobsStatsSummary, less func(a, b *parser.WorkflowJobsStatsSummary) bool) []*parser.WorkflowJobsStatsSummary {
+	sorted := make([]*parser.WorkflowJobsStatsSummary, len(jobs))
+	copy(sorted, jobs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if less(sorted[i], sorted[j]) {
+			return true
+		}
+		if less(sorted[j], sorted[i]) {
+			return false
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// worstStep returns the step with the most failures, or nil when no step failed
+func worstStep(job *parser.WorkflowJobsStatsSummary) *parser.StepSummary {
+	var worst *parser.StepSummary
+	for _, step := range job.StepSummary {
+		if step.Conclusions[parser.ConclusionFailure] == 0 {
+			continue
+		}
+		if worst == nil || step.Conclusions[parser.ConclusionFailure] > worst.Conclusions[parser.ConclusionFailure] {
+			worst = step
+		}
+	}
+	return worst
+}
+
+func failureLinks(urls []string) string {
+	if len(urls) == 0 {
+		return "-"
+	}
+	links := make([]string, 0, maxFailureLinks)
+	for i, u := range urls[:min(len(urls), maxFailureLinks)] {
+		links = append(links, fmt.Sprintf("[#%d](%s)", i+1, u))
+	}
+	if len(urls) > maxFailureLinks {
+		links = append(links, fmt.Sprintf("and %d more", len(urls)-maxFailureLinks))
+	}
+	return strings.Join(links, " ")
+}
+
+// percentileHeaders returns the table header cells of the percentiles, e.g. " P95 | P99 |"
+func percentileHeaders(ps []parser.Percentile) string {
+	var b strings.Builder
+	for _, p := range ps {
+		b.WriteString(" " + strings.ToUpper(p.Label()) + " |")
+	}
+	return b.String()
+}
+
+// percentileCells returns the table cells of the percentile values
+func percentileCells(ps []parser.Percentile, format string) string {
+	var b strings.Builder
+	for _, p := range ps {
+		b.WriteString(" " + fmt.Sprintf(format, p.Value) + " |")
+	}
+	return b.String()
+}
+
+// escapeMarkdown escapes the characters that break a table cell
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
@@ -0,0 +1,116 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunsMarkdown(t *testing.T) {
+	wrs := &parser.WorkflowRunsStatsSummary{
+		TotalRunsCount: 10,
+		Name:           "CI | main",
+		Rate:           parser.Rate{SuccesRate: 0.7, FailureRate: 0.2, OthersRate: 0.1},
+		ExecutionDurationStats: parser.ExecutionDurationStats{
+			Min: 10, Max: 100, Avg: 50, Med: 45, Std: 12.34,
+		},
+		Conclusions: map[string]*parser.WorkflowRunsConclusion{
+			parser.ConclusionSuccess: {RunsCount: 7},
+			parser.ConclusionFailure: {RunsCount: 2},
+			parser.ConclusionOthers:  {RunsCount: 1},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	RunsMarkdown(w, wrs)
+
+	assert.Equal(t, "### 🏃 CI \\| main workflow runs\n\n"+
+		"Total runs: **10**\n\n"+
+		"| Conclusion | Runs | Rate |\n"+
+		"| --- | ---: | ---: |\n"+
+		"| ✔ Success | 7 | 70.0% |\n"+
+		"| ✖ Failure | 2 | 20.0% |\n"+
+		"| 🤔 Others | 1 | 10.0% |\n"+
+		"\n### ⏰ Workflow run execution time stats\n\n"+
+		"| Min | Max | Avg | Med | Std |\n"+
+		"| ---: | ---: | ---: | ---: | ---: |\n"+
+		"| 10.0s | 100.0s | 50.0s | 45.0s | 12.3s |\n", w.String())
+}
+
+func TestFailureJobsMarkdown(t *testing.T) {
+	urls := []string{"https://example.com/1", "https://example.com/2", "https://example.com/3", "https://example.com/4", "https://example.com/5", "https://example.com/6"}
+	jobs := []*parser.WorkflowJobsStatsSummary{
+		{
+			Name:           "lint",
+			TotalRunsCount: 10,
+			Conclusions:    map[string]int{"failure": 0},
+		},
+		{
+			Name:           "test",
+			TotalRunsCount: 10,
+			Conclusions:    map[string]int{"failure": 6},
+			StepSummary: []*parser.StepSummary{
+				{Name: "checkout", RunsCount: 10, Conclusions: map[string]int{"failure": 0}},
+				{Name: "go test", RunsCount: 10, Conclusions: map[string]int{"failure": 6}, FailureHTMLURL: urls},
+			},
+		},
+		{
+			Name:           "build",
+			TotalRunsCount: 10,
+			Conclusions:    map[string]int{"failure": 6},
+			StepSummary: []*parser.StepSummary{
+				{Name: "make", RunsCount: 10, Conclusions: map[string]int{"failure": 1}, FailureHTMLURL: urls[:1]},
+			},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	FailureJobsMarkdown(w, jobs, 3)
+
+	assert.Equal(t, "\n### 📈 Top 3 jobs with the highest failure counts\n\n"+
+		"| Job | Failures | Worst step | Step failures | Failed jobs |\n"+
+		"| --- | ---: | --- | ---: | --- |\n"+
+		"| build | 6/10 | make | 1/10 | [#1](https://example.com/1) |\n"+
+		"| test | 6/10 | go test | 6/10 | [#1](https://example.com/1) [#2](https://example.com/2) [#3](https://example.com/3) [#4](https://example.com/4) [#5](https://example.com/5) and 1 more |\n"+
+		"| lint | 0/10 | - | - | - |\n", w.String())
+	assert.Equal(t, "lint", jobs[0].Name, "input must not be reordered")
+}
+
+func TestLongestDurationJobsMarkdown(t *testing.T) {
+	jobs := []*parser.WorkflowJobsStatsSummary{
+		{Name: "b", ExecutionDurationStats: parser.ExecutionDurationStats{Avg: 10, Med: 9, Max: 20}},
+		{Name: "a", ExecutionDurationStats: parser.ExecutionDurationStats{Avg: 10, Med: 8, Max: 30}},
+		{Name: "c", ExecutionDurationStats: parser.ExecutionDurationStats{Avg: 30, Med: 30, Max: 30}},
+	}
+
+	w := &bytes.Buffer{}
+	LongestDurationJobsMarkdown(w, jobs, 2)
+
+	assert.Equal(t, "\n### 📊 Top 2 jobs with the longest execution average duration\n\n"+
+		"| Job | Avg | Med | Max |\n"+
+		"| --- | ---: | ---: | ---: |\n"+
+		"| c | 30.00s | 30.00s | 30.00s |\n"+
+		"| a | 10.00s | 8.00s | 30.00s |\n", w.String())
+}
+
+func TestLongestDurationJobsMarkdown_Percentiles(t *testing.T) {
+	jobs := []*parser.WorkflowJobsStatsSummary{
+		{Name: "a", ExecutionDurationStats: parser.ExecutionDurationStats{Avg: 10, Med: 8, Max: 30, Percentiles: []parser.Percentile{{P: 95, Value: 28}}}},
+	}
+
+	w := &bytes.Buffer{}
+	LongestDurationJobsMarkdown(w, jobs, 1)
+
+	assert.Equal(t, "\n### 📊 Top 1 jobs with the longest execution average duration\n\n"+
+		"| Job | Avg | Med | Max | P95 |\n"+
+		"| --- | ---: | ---: | ---: | ---: |\n"+
+		"| a | 10.00s | 8.00s | 30.00s | 28.00s |\n", w.String())
+}
+
+func TestRateLimitWarningMarkdown(t *testing.T) {
+	w := &bytes.Buffer{}
+	RateLimitWarningMarkdown(w)
+	assert.Equal(t, "> [!WARNING]\n> You have reached the rate limit for the GitHub API. These results may not be accurate.\n\n", w.String())
+}
@@ -0,0 +1,39 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+// MatrixLegs prints the legs of the first n jobs with legs, by job name
+func MatrixLegs(w io.Writer, jobs []*parser.WorkflowJobsStatsSummary, n int) {
+	withLegs := make([]*parser.WorkflowJobsStatsSummary, 0, len(jobs))
+	for _, j := range jobs {
+		if len(j.Legs) > 0 {
+			withLegs = append(withLegs, j)
+		}
+	}
+	sort.Slice(withLegs, func(i, j int) bool {
+		return withLegs[i].Name < withLegs[j].Name
+	})
+	jobsNum := min(len(withLegs), n)
+	_, _ = fmt.Fprintf(w, "\n%s Matrix breakdown of %d jobs\n", "\U0001F9EE", jobsNum)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  Job / leg\tRuns\tSuccess\tAvg")
+	for _, j := range withLegs[:jobsNum] {
+		matrixRow(tw, "  ", j)
+		for _, l := range j.Legs {
+			matrixRow(tw, "    ", l)
+		}
+	}
+	_ = tw.Flush()
+}
+
+func matrixRow(w io.Writer, indent string, j *parser.WorkflowJobsStatsSummary) {
+	_, _ = fmt.Fprintf(w, "%s%s\t%d\t%.1f%%\t%.1fs\n", indent, j.Name, j.TotalRunsCount, j.Rate.SuccesRate*100, j.ExecutionDurationStats.Avg)
+}
@@ -0,0 +1,34 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrixLegs(t *testing.T) {
+	leg := func(name string, runs int, success, avg float64) *parser.WorkflowJobsStatsSummary {
+		return &parser.WorkflowJobsStatsSummary{
+			Name:                   name,
+			TotalRunsCount:         runs,
+			Rate:                   parser.Rate{SuccesRate: success},
+			ExecutionDurationStats: parser.ExecutionDurationStats{Avg: avg},
+		}
+	}
+	build := leg("build", 3, 2.0/3, 120)
+	build.Legs = []*parser.WorkflowJobsStatsSummary{leg("build (ubuntu-latest)", 1, 1, 60), leg("build (windows-latest)", 2, 0.5, 180)}
+	test := leg("test", 1, 1, 30)
+	test.Legs = []*parser.WorkflowJobsStatsSummary{leg("test (macos-latest)", 1, 1, 30)}
+	jobs := []*parser.WorkflowJobsStatsSummary{test, leg("lint", 1, 1, 10), build}
+
+	w := &bytes.Buffer{}
+	MatrixLegs(w, jobs, 1)
+
+	assert.Equal(t, "\n🧮 Matrix breakdown of 1 jobs\n"+
+		"  Job / leg                 Runs  Success  Avg\n"+
+		"  build                     3     66.7%    120.0s\n"+
+		"    build (ubuntu-latest)   1     100.0%   60.0s\n"+
+		"    build (windows-latest)  2     50.0%    180.0s\n", w.String())
+}
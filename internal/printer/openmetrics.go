@@ -0,0 +1,200 @@
+package printer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// durationBuckets are the upper bounds in seconds of the workflow run duration histogram
+var durationBuckets = []float64{30, 60, 120, 300, 600, 900, 1200, 1800, 2700, 3600, 7200}
+
+// WorkflowMetrics holds the stats of a workflow exposed as metrics
+type WorkflowMetrics struct {
+	Repository  string
+	Workflow    string
+	RateLimited bool
+	Runs        *parser.WorkflowRunsStatsSummary
+	Jobs        []*parser.WorkflowJobsStatsSummary
+}
+
+type metricLabel struct {
+	name, value string
+}
+
+type metricSample struct {
+	suffix string
+	labels []metricLabel
+	value  float64
+}
+
+type metricFamily struct {
+	name    string
+	typ     string
+	unit    string
+	help    string
+	samples []metricSample
+}
+
+// OpenMetrics writes the stats of the workflows in the OpenMetrics text format.
+// Run counts and rates are gauges because they describe the fetched window of runs, not a monotonic total.
+func OpenMetrics(w io.Writer, ms []*WorkflowMetrics) error {
+	families := []*metricFamily{
+		{name: "gh_workflow_stats_rate_limited", typ: "gauge", help: "Whether the GitHub API rate limit was reached while fetching the workflow runs."},
+		{name: "gh_workflow_stats_runs", typ: "gauge", help: "Number of workflow runs by conclusion."},
+		{name: "gh_workflow_stats_run_success_ratio", typ: "gauge", help: "Ratio of successful workflow runs."},
+		{name: "gh_workflow_stats_run_failure_ratio", typ: "gauge", help: "Ratio of failed workflow runs."},
+		{name: "gh_workflow_stats_run_duration_seconds", typ: "histogram", unit: "seconds", help: "Execution time of successful workflow runs."},
+		{name: "gh_workflow_stats_run_job_time_seconds", typ: "gauge", unit: "seconds", help: "Sum of the job execution times of successful workflow runs."},
+		{name: "gh_workflow_stats_job_runs", typ: "gauge", help: "Number of job executions by conclusion."},
+		{name: "gh_workflow_stats_job_success_ratio", typ: "gauge", help: "Ratio of successful job executions."},
+		{name: "gh_workflow_stats_job_duration_seconds", typ: "gauge", unit: "seconds", help: "Execution time stats of successful job executions."},
+		{name: "gh_workflow_stats_step_runs", typ: "gauge", help: "Number of step executions by conclusion."},
+		{name: "gh_workflow_stats_step_success_ratio", typ: "gauge", help: "Ratio of successful step executions."},
+		{name: "gh_workflow_stats_step_duration_seconds", typ: "gauge", unit: "seconds", help: "Execution time stats of completed step executions."},
+	}
+	rateLimited, runs, successRatio, failureRatio, duration, jobTime := families[0], families[1], families[2], families[3], families[4], families[5]
+	jobRuns, jobSuccess, jobDuration := families[6], families[7], families[8]
+	stepRuns, stepSuccess, stepDuration := families[9], families[10], families[11]
+
+	for _, m := range ms {
+		base := []metricLabel{{"repo", m.Repository}, {"workflow", m.Workflow}}
+		rateLimited.add("", base, boolValue(m.RateLimited))
+
+		if m.Runs != nil {
+			for _, c := range []string{parser.ConclusionSuccess, parser.ConclusionFailure, parser.ConclusionOthers} {
+				n := 0
+				if wrc, ok := m.Runs.Conclusions[c]; ok {
+					n = wrc.RunsCount
+				}
+				runs.add("", with(base, metricLabel{"conclusion", c}), float64(n))
+			}
+			successRatio.add("", base, m.Runs.Rate.SuccesRate)
+			failureRatio.add("", base, m.Runs.Rate.FailureRate)
+			addHistogram(duration, base, parser.SuccessDurations(m.Runs))
+			if m.Runs.JobTimeStats != nil {
+				addDurationStats(jobTime, base, *m.Runs.JobTimeStats)
+			}
+		}
+
+		jobs := make([]*parser.WorkflowJobsStatsSummary, len(m.Jobs))
+		copy(jobs, m.Jobs)
+		sort.SliceStable(jobs, func(i, j int) bool {
+			return jobs[i].Name < jobs[j].Name
+		})
+		for _, j := range jobs {
+			jl := with(base, metricLabel{"job", j.Name})
+			for _, c := range []string{parser.ConclusionSuccess, parser.ConclusionFailure, parser.ConclusionOthers} {
+				jobRuns.add("", with(jl, metricLabel{"conclusion", c}), float64(j.Conclusions[c]))
+			}
+			jobSuccess.add("", jl, j.Rate.SuccesRate)
+			addDurationStats(jobDuration, jl, j.ExecutionDurationStats)
+
+			for _, s := range j.StepSummary {
+				sl := with(jl, metricLabel{"step", s.Name})
+				for _, c := range []string{parser.ConclusionSuccess, parser.ConclusionFailure, parser.ConclusionOthers} {
+					stepRuns.add("", with(sl, metricLabel{"conclusion", c}), float64(s.Conclusions[c]))
+				}
+				stepSuccess.add("", sl, s.Rate.SuccesRate)
+				addDurationStats(stepDuration, sl, s.ExecutionDurationStats)
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		if len(f.samples) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(bw, "# TYPE %s %s\n", f.name, f.typ)
+		if f.unit != "" {
+			_, _ = fmt.Fprintf(bw, "# UNIT %s %s\n", f.name, f.unit)
+		}
+		_, _ = fmt.Fprintf(bw, "# HELP %s %s\n", f.name, f.help)
+		for _, s := range f.samples {
+			_, _ = fmt.Fprintf(bw, "%s%s%s %s\n", f.name, s.suffix, formatLabels(s.labels), formatValue(s.value))
+		}
+	}
+	_, _ = fmt.Fprintln(bw, "# EOF")
+	return bw.Flush()
+}
+
+func (f *metricFamily) add(suffix string, labels []metricLabel, v float64) {
+	f.samples = append(f.samples, metricSample{suffix: suffix, labels: labels, value: v})
+}
+
+// addHistogram adds the cumulative buckets, the count and the sum of the durations
+func addHistogram(f *metricFamily, labels []metricLabel, durations []float64) {
+	sum := 0.0
+	for _, d := range durations {
+		sum += d
+	}
+	for _, le := range durationBuckets {
+		n := 0
+		for _, d := range durations {
+			if d <= le {
+				n++
+			}
+		}
+		f.add("_bucket", with(labels, metricLabel{"le", formatValue(le)}), float64(n))
+	}
+	f.add("_bucket", with(labels, metricLabel{"le", "+Inf"}), float64(len(durations)))
+	f.add("_count", labels, float64(len(durations)))
+	f.add("_sum", labels, sum)
+}
+
+func addDurationStats(f *metricFamily, labels []metricLabel, s parser.ExecutionDurationStats) {
+	f.add("", with(labels, metricLabel{"stat", "min"}), s.Min)
+	f.add("", with(labels, metricLabel{"stat", "max"}), s.Max)
+	f.add("", with(labels, metricLabel{"stat", "avg"}), s.Avg)
+	f.add("", with(labels, metricLabel{"stat", "med"}), s.Med)
+	for _, p := range s.Percentiles {
+		f.add("", with(labels, metricLabel{"stat", p.Label()}), p.Value)
+	}
+}
+
+// with returns a copy of labels with l appended
+func with(labels []metricLabel, l metricLabel) []metricLabel {
+	res := make([]metricLabel, 0, len(labels)+1)
+	res = append(res, labels...)
+	return append(res, l)
+}
+
+func formatLabels(labels []metricLabel) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	parts := make([]string, 0, len(labels))
+	for _, l := range labels {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, l.name, escaper.Replace(l.value)))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,135 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenMetrics(t *testing.T) {
+	ms := []*WorkflowMetrics{
+		{
+			Repository: "owner/repo",
+			Workflow:   `CI "main"`,
+			Runs: &parser.WorkflowRunsStatsSummary{
+				TotalRunsCount: 3,
+				Rate:           parser.Rate{SuccesRate: 0.6667, FailureRate: 0.3333},
+				Conclusions: map[string]*parser.WorkflowRunsConclusion{
+					parser.ConclusionSuccess: {RunsCount: 2, WorkflowRuns: []*parser.WorkflowRun{
+						{Status: "completed", Duration: 45},
+						{Status: "completed", Duration: 100.5},
+					}},
+					parser.ConclusionFailure: {RunsCount: 1},
+				},
+			},
+			Jobs: []*parser.WorkflowJobsStatsSummary{
+				{
+					Name:                   "build",
+					Conclusions:            map[string]int{"success": 2, "failure": 1, "others": 0},
+					Rate:                   parser.Rate{SuccesRate: 0.6667},
+					ExecutionDurationStats: parser.ExecutionDurationStats{Min: 10, Max: 20, Avg: 15, Med: 15},
+					StepSummary: []*parser.StepSummary{
+						{
+							Name:                   "test",
+							Conclusions:            map[string]int{"success": 2, "failure": 1, "others": 0},
+							Rate:                   parser.Rate{SuccesRate: 0.6667},
+							ExecutionDurationStats: parser.ExecutionDurationStats{Min: 5, Max: 8, Avg: 6, Med: 6},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	require.NoError(t, OpenMetrics(w, ms))
+
+	l := `repo="owner/repo",workflow="CI \"main\""`
+	assert.Equal(t, "# TYPE gh_workflow_stats_rate_limited gauge\n"+
+		"# HELP gh_workflow_stats_rate_limited Whether the GitHub API rate limit was reached while fetching the workflow runs.\n"+
+		"gh_workflow_stats_rate_limited{"+l+"} 0\n"+
+		"# TYPE gh_workflow_stats_runs gauge\n"+
+		"# HELP gh_workflow_stats_runs Number of workflow runs by conclusion.\n"+
+		"gh_workflow_stats_runs{"+l+`,conclusion="success"} 2`+"\n"+
+		"gh_workflow_stats_runs{"+l+`,conclusion="failure"} 1`+"\n"+
+		"gh_workflow_stats_runs{"+l+`,conclusion="others"} 0`+"\n"+
+		"# TYPE gh_workflow_stats_run_success_ratio gauge\n"+
+		"# HELP gh_workflow_stats_run_success_ratio Ratio of successful workflow runs.\n"+
+		"gh_workflow_stats_run_success_ratio{"+l+"} 0.6667\n"+
+		"# TYPE gh_workflow_stats_run_failure_ratio gauge\n"+
+		"# HELP gh_workflow_stats_run_failure_ratio Ratio of failed workflow runs.\n"+
+		"gh_workflow_stats_run_failure_ratio{"+l+"} 0.3333\n"+
+		"# TYPE gh_workflow_stats_run_duration_seconds histogram\n"+
+		"# UNIT gh_workflow_stats_run_duration_seconds seconds\n"+
+		"# HELP gh_workflow_stats_run_duration_seconds Execution time of successful workflow runs.\n"+
+		"gh_workflow_stats_run_duration_seconds_bucket{"+l+`,le="30"} 0`+"\n"+
+		"gh_workflow_stats_run_duration_seconds_bucket{"+l+`,le="60"} 1`+"\n"+
+		"gh_workflow_stats_run_duration_seconds_bucket{"+l+`,le="120"} 2`+"\n"+
+		"gh_workflow_stats_run_duration_seconds_bucket{"+l+`,le="300"} 2`+"\n"+
+		"gh_workflow_stats_run_duration_seconds_bucket{"+l+`,le="600"} 2`+"\n"+
+		"gh_workflow_stats_run_duration_seconds_bucket{"+l+`,le="900"} 2`+"\n"+
+		"gh_workflow_stats_run_duration_seconds_bucket{"+l+`,le="1200"} 2`+"\n"+
+		"gh_workflow_stats_run_duration_seconds_bucket{"+l+`,le="1800"} 2`+"\n"+
+		"gh_workflow_stats_run_duration_seconds_bucket{"+l+`,le="2700"} 2`+"\n"+
+		"gh_workflow_stats_run_duration_seconds_bucket{"+l+`,le="3600"} 2`+"\n"+
+		"gh_workflow_stats_run_duration_seconds_bucket{"+l+`,le="7200"} 2`+"\n"+
+		"gh_workflow_stats_run_duration_seconds_bucket{"+l+`,le="+Inf"} 2`+"\n"+
+		"gh_workflow_stats_run_duration_seconds_count{"+l+"} 2\n"+
+		"gh_workflow_stats_run_duration_seconds_sum{"+l+"} 145.5\n"+
+		"# TYPE gh_workflow_stats_job_runs gauge\n"+
+		"# HELP gh_workflow_stats_job_runs Number of job executions by conclusion.\n"+
+		"gh_workflow_stats_job_runs{"+l+`,job="build",conclusion="success"} 2`+"\n"+
+		"gh_workflow_stats_job_runs{"+l+`,job="build",conclusion="failure"} 1`+"\n"+
+		"gh_workflow_stats_job_runs{"+l+`,job="build",conclusion="others"} 0`+"\n"+
+		"# TYPE gh_workflow_stats_job_success_ratio gauge\n"+
+		"# HELP gh_workflow_stats_job_success_ratio Ratio of successful job executions.\n"+
+		"gh_workflow_stats_job_success_ratio{"+l+`,job="build"} 0.6667`+"\n"+
+		"# TYPE gh_workflow_stats_job_duration_seconds gauge\n"+
+		"# UNIT gh_workflow_stats_job_duration_seconds seconds\n"+
+		"# HELP gh_workflow_stats_job_duration_seconds Execution time stats of successful job executions.\n"+
+		"gh_workflow_stats_job_duration_seconds{"+l+`,job="build",stat="min"} 10`+"\n"+
+		"gh_workflow_stats_job_duration_seconds{"+l+`,job="build",stat="max"} 20`+"\n"+
+		"gh_workflow_stats_job_duration_seconds{"+l+`,job="build",stat="avg"} 15`+"\n"+
+		"gh_workflow_stats_job_duration_seconds{"+l+`,job="build",stat="med"} 15`+"\n"+
+		"# TYPE gh_workflow_stats_step_runs gauge\n"+
+		"# HELP gh_workflow_stats_step_runs Number of step executions by conclusion.\n"+
+		"gh_workflow_stats_step_runs{"+l+`,job="build",step="test",conclusion="success"} 2`+"\n"+
+		"gh_workflow_stats_step_runs{"+l+`,job="build",step="test",conclusion="failure"} 1`+"\n"+
+		"gh_workflow_stats_step_runs{"+l+`,job="build",step="test",conclusion="others"} 0`+"\n"+
+		"# TYPE gh_workflow_stats_step_success_ratio gauge\n"+
+		"# HELP gh_workflow_stats_step_success_ratio Ratio of successful step executions.\n"+
+		"gh_workflow_stats_step_success_ratio{"+l+`,job="build",step="test"} 0.6667`+"\n"+
+		"# TYPE gh_workflow_stats_step_duration_seconds gauge\n"+
+		"# UNIT gh_workflow_stats_step_duration_seconds seconds\n"+
+		"# HELP gh_workflow_stats_step_duration_seconds Execution time stats of completed step executions.\n"+
+		"gh_workflow_stats_step_duration_seconds{"+l+`,job="build",step="test",stat="min"} 5`+"\n"+
+		"gh_workflow_stats_step_duration_seconds{"+l+`,job="build",step="test",stat="max"} 8`+"\n"+
+		"gh_workflow_stats_step_duration_seconds{"+l+`,job="build",step="test",stat="avg"} 6`+"\n"+
+		"gh_workflow_stats_step_duration_seconds{"+l+`,job="build",step="test",stat="med"} 6`+"\n"+
+		"# EOF\n", w.String())
+}
+
+func TestOpenMetrics_Empty(t *testing.T) {
+	w := &bytes.Buffer{}
+	require.NoError(t, OpenMetrics(w, nil))
+	assert.Equal(t, "# EOF\n", w.String())
+}
+
+func TestOpenMetrics_Percentiles(t *testing.T) {
+	ms := []*WorkflowMetrics{{
+		Repository: "owner/repo",
+		Workflow:   "CI",
+		Jobs: []*parser.WorkflowJobsStatsSummary{{
+			Name:                   "build",
+			Conclusions:            map[string]int{},
+			ExecutionDurationStats: parser.ExecutionDurationStats{Percentiles: []parser.Percentile{{P: 95, Value: 42.5}}},
+		}},
+	}}
+
+	w := &bytes.Buffer{}
+	require.NoError(t, OpenMetrics(w, ms))
+	assert.Contains(t, w.String(), `gh_workflow_stats_job_duration_seconds{repo="owner/repo",workflow="CI",job="build",stat="p95"} 42.5`+"\n")
+}
@@ -0,0 +1,98 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+func Organization(w io.Writer, oss *parser.OrganizationStatsSummary) {
+	repos := make([]*parser.RepositoryStatsSummary, len(oss.RepositoriesSummary))
+	copy(repos, oss.RepositoriesSummary)
+	sort.SliceStable(repos, func(i, j int) bool {
+		return repos[i].Rate.FailureRate > repos[j].Rate.FailureRate
+	})
+
+	_, _ = fmt.Fprintf(w, "%s Repositories: %d\n", "\U0001F3E2", oss.RepositoriesCount)
+
+	// Repositories that could not be fetched have no percentiles, the others all have the same
+	var ps []parser.Percentile
+	for _, r := range repos {
+		if len(r.ExecutionDurationStats.Percentiles) > 0 {
+			ps = r.ExecutionDurationStats.Percentiles
+			break
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "  Repository\tWorkflows\tRuns\tSuccess\tFailure\tOthers\tMed%s\tStatus\tMost failing job\n", percentileColumns(ps))
+	for _, r := range repos {
+		rps := r.ExecutionDurationStats.Percentiles
+		if len(rps) == 0 {
+			rps = make([]parser.Percentile, len(ps))
+		}
+		_, _ = fmt.Fprintf(tw, "  %s\t%d\t%d\t%.1f%%\t%.1f%%\t%.1f%%\t%.1fs%s\t%s\t%s\n",
+			r.Repository,
+			len(r.Workflows),
+			r.TotalRunsCount,
+			r.Rate.SuccesRate*100,
+			r.Rate.FailureRate*100,
+			r.Rate.OthersRate*100,
+			r.ExecutionDurationStats.Med,
+			percentileValues(rps),
+			repositoryStatus(r),
+			mostFailingJob(r),
+		)
+	}
+	_ = tw.Flush()
+
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	_, _ = fmt.Fprintf(w, "\n%s Organization total runs: %d\n", "\U0001F3C3", oss.TotalRunsCount)
+	_, _ = fmt.Fprintf(w, conclusionFormat, green("✔ Success"), oss.Conclusions[parser.ConclusionSuccess], oss.Rate.SuccesRate*100)
+	_, _ = fmt.Fprintf(w, conclusionFormat, red("✖ Failure"), oss.Conclusions[parser.ConclusionFailure], oss.Rate.FailureRate*100)
+	_, _ = fmt.Fprintf(w, conclusionFormat, yellow("\U0001F914 Others"), oss.Conclusions[parser.ConclusionOthers], oss.Rate.OthersRate*100)
+
+	if oss.RateLimitedCount > 0 {
+		_, _ = fmt.Fprintf(w, "\n\U000026A0  %d repositories have partial results because the GitHub API rate limit was reached.\n", oss.RateLimitedCount)
+	}
+	if oss.FailedCount > 0 {
+		_, _ = fmt.Fprintf(w, "\n\U000026A0  %d repositories could not be analyzed. Use --json to see the errors.\n", oss.FailedCount)
+	}
+}
+
+func repositoryStatus(r *parser.RepositoryStatsSummary) string {
+	switch {
+	case r.Error != "":
+		return "error"
+	case r.RateLimited:
+		return "rate limited"
+	default:
+		return "ok"
+	}
+}
+
+// mostFailingJob names the job with the most failures across the workflows of the repository with its failures / runs.
+// Ties go to the first job by workflow and job name.
+func mostFailingJob(r *parser.RepositoryStatsSummary) string {
+	name, failures, runs := "", 0, 0
+	for _, wf := range r.Workflows {
+		for _, j := range wf.Jobs {
+			n := wf.Name + " / " + j.Name
+			f := j.Conclusions[parser.ConclusionFailure]
+			if f > failures || (f == failures && f > 0 && n < name) {
+				name, failures, runs = n, f, j.TotalRunsCount
+			}
+		}
+	}
+	if failures == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%s: %d/%d", name, failures, runs)
+}
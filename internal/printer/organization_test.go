@@ -0,0 +1,85 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganization(t *testing.T) {
+	oss := &parser.OrganizationStatsSummary{
+		Organization:      "owner",
+		RepositoriesCount: 3,
+		RateLimitedCount:  1,
+		FailedCount:       1,
+		TotalRunsCount:    4,
+		Rate:              parser.Rate{SuccesRate: 0.5, FailureRate: 0.25, OthersRate: 0.25},
+		Conclusions:       map[string]int{"success": 2, "failure": 1, "others": 1},
+		RepositoriesSummary: []*parser.RepositoryStatsSummary{
+			{Repository: "api", TotalRunsCount: 1, Rate: parser.Rate{OthersRate: 1}, RateLimited: true},
+			{Repository: "docs", Error: "not found"},
+			{
+				Repository:             "web",
+				TotalRunsCount:         3,
+				Rate:                   parser.Rate{SuccesRate: 0.6667, FailureRate: 0.3333},
+				ExecutionDurationStats: parser.ExecutionDurationStats{Med: 42},
+				Workflows: []*parser.WorkflowStatsSummary{
+					{Name: "CI", Jobs: []*parser.WorkflowJobsStatsSummary{
+						{Name: "lint", TotalRunsCount: 3, Conclusions: map[string]int{"success": 3}},
+						{Name: "test", TotalRunsCount: 3, Conclusions: map[string]int{"success": 2, "failure": 1}},
+					}},
+					{Name: "Release", Jobs: []*parser.WorkflowJobsStatsSummary{
+						{Name: "publish", TotalRunsCount: 1, Conclusions: map[string]int{"failure": 1}},
+					}},
+				},
+			},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	Organization(w, oss)
+
+	assert.Equal(t, "🏢 Repositories: 3\n"+
+		"  Repository  Workflows  Runs  Success  Failure  Others  Med    Status        Most failing job\n"+
+		"  web         2          3     66.7%    33.3%    0.0%    42.0s  ok            CI / test: 1/3\n"+
+		"  api         0          1     0.0%     0.0%     100.0%  0.0s   rate limited  -\n"+
+		"  docs        0          0     0.0%     0.0%     0.0%    0.0s   error         -\n"+
+		"\n🏃 Organization total runs: 4\n  ✔ Success: 2 (50.0%)\n  ✖ Failure: 1 (25.0%)\n  🤔 Others: 1 (25.0%)\n"+
+		"\n⚠  1 repositories have partial results because the GitHub API rate limit was reached.\n"+
+		"\n⚠  1 repositories could not be analyzed. Use --json to see the errors.\n", w.String())
+}
+
+func TestOrganization_Percentiles(t *testing.T) {
+	oss := &parser.OrganizationStatsSummary{
+		Organization:      "owner",
+		RepositoriesCount: 2,
+		FailedCount:       1,
+		TotalRunsCount:    3,
+		Rate:              parser.Rate{SuccesRate: 1},
+		Conclusions:       map[string]int{"success": 3, "failure": 0, "others": 0},
+		RepositoriesSummary: []*parser.RepositoryStatsSummary{
+			{Repository: "docs", Error: "not found"},
+			{
+				Repository:     "web",
+				TotalRunsCount: 3,
+				Rate:           parser.Rate{SuccesRate: 1},
+				ExecutionDurationStats: parser.ExecutionDurationStats{
+					Med:         42,
+					Percentiles: []parser.Percentile{{P: 95, Value: 60}},
+				},
+			},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	Organization(w, oss)
+
+	assert.Equal(t, "🏢 Repositories: 2\n"+
+		"  Repository  Workflows  Runs  Success  Failure  Others  Med    P95    Status  Most failing job\n"+
+		"  docs        0          0     0.0%     0.0%     0.0%    0.0s   0.0s   error   -\n"+
+		"  web         0          3     100.0%   0.0%     0.0%    42.0s  60.0s  ok      -\n"+
+		"\n🏃 Organization total runs: 3\n  ✔ Success: 3 (100.0%)\n  ✖ Failure: 0 (0.0%)\n  🤔 Others: 0 (0.0%)\n"+
+		"\n⚠  1 repositories could not be analyzed. Use --json to see the errors.\n", w.String())
+}
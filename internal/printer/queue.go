@@ -0,0 +1,35 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+func Queue(w io.Writer, qs *parser.QueueSummary) {
+	_, _ = fmt.Fprintf(w, "\n%s Job queue time stats (%d jobs)\n", "⏳", qs.JobsCount)
+	executionStats(w, qs.QueueDurationStats)
+
+	queueTable(w, fmt.Sprintf("\n%s Queue time by runner label\n", "\U0001F5A5"), "Runner", qs.Runners)
+	queueTable(w, fmt.Sprintf("\n%s Queue time by hour of the day (%s)\n", "\U0001F552", qs.TimeZone), "Hour", qs.Hours)
+}
+
+func queueTable(w io.Writer, title, header string, qbs []*parser.QueueBreakdown) {
+	_, _ = fmt.Fprint(w, title)
+
+	// Every breakdown has the same percentiles
+	var ps []parser.Percentile
+	if len(qbs) > 0 {
+		ps = qbs[0].QueueDurationStats.Percentiles
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "  %s\tJobs\tAvg\tMed%s\tMax\n", header, percentileColumns(ps))
+	for _, qb := range qbs {
+		s := qb.QueueDurationStats
+		_, _ = fmt.Fprintf(tw, "  %s\t%d\t%.1fs\t%.1fs%s\t%.1fs\n", qb.Name, qb.JobsCount, s.Avg, s.Med, percentileValues(s.Percentiles), s.Max)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,40 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue(t *testing.T) {
+	stats := func(med float64) parser.ExecutionDurationStats {
+		return parser.ExecutionDurationStats{Min: med, Max: med, Avg: med, Med: med, Percentiles: []parser.Percentile{{P: 95, Value: med}}}
+	}
+	qs := &parser.QueueSummary{
+		JobsCount:          3,
+		TimeZone:           "UTC",
+		QueueDurationStats: stats(10),
+		Runners: []*parser.QueueBreakdown{
+			{Name: "self-hosted,linux", JobsCount: 2, QueueDurationStats: stats(600)},
+			{Name: "ubuntu-latest", JobsCount: 1, QueueDurationStats: stats(5)},
+		},
+		Hours: []*parser.QueueBreakdown{
+			{Name: "09:00", JobsCount: 3, QueueDurationStats: stats(10)},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	Queue(w, qs)
+
+	assert.Equal(t, "\n⏳ Job queue time stats (3 jobs)\n"+
+		"  Min: 10.0s\n  Max: 10.0s\n  Avg: 10.0s\n  Med: 10.0s\n  Std: 0.0s\n  P95: 10.0s\n"+
+		"\n🖥 Queue time by runner label\n"+
+		"  Runner             Jobs  Avg     Med     P95     Max\n"+
+		"  self-hosted,linux  2     600.0s  600.0s  600.0s  600.0s\n"+
+		"  ubuntu-latest      1     5.0s    5.0s    5.0s    5.0s\n"+
+		"\n🕒 Queue time by hour of the day (UTC)\n"+
+		"  Hour   Jobs  Avg    Med    P95    Max\n"+
+		"  09:00  3     10.0s  10.0s  10.0s  10.0s\n", w.String())
+}
@@ -0,0 +1,91 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+func Repository(w io.Writer, rss *parser.RepositoryStatsSummary) {
+	workflows := make([]*parser.WorkflowStatsSummary, len(rss.Workflows))
+	copy(workflows, rss.Workflows)
+	sort.SliceStable(workflows, func(i, j int) bool {
+		return workflows[i].Rate.FailureRate > workflows[j].Rate.FailureRate
+	})
+
+	_, _ = fmt.Fprintf(w, "%s Workflows: %d\n", "\U0001F5C2", len(workflows))
+
+	// Every workflow has the same percentiles
+	var ps []parser.Percentile
+	if len(workflows) > 0 {
+		ps = workflows[0].ExecutionDurationStats.Percentiles
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "  Workflow\tRuns\tSuccess\tFailure\tOthers\tAvg\tMed%s\tMax\n", percentileColumns(ps))
+	for _, wf := range workflows {
+		_, _ = fmt.Fprintf(tw, "  %s\t%d\t%.1f%%\t%.1f%%\t%.1f%%\t%.1fs\t%.1fs%s\t%.1fs\n",
+			wf.Name,
+			wf.TotalRunsCount,
+			wf.Rate.SuccesRate*100,
+			wf.Rate.FailureRate*100,
+			wf.Rate.OthersRate*100,
+			wf.ExecutionDurationStats.Avg,
+			wf.ExecutionDurationStats.Med,
+			percentileValues(wf.ExecutionDurationStats.Percentiles),
+			wf.ExecutionDurationStats.Max,
+		)
+	}
+	_ = tw.Flush()
+
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	_, _ = fmt.Fprintf(w, "\n%s Repository total runs: %d\n", "\U0001F3C3", rss.TotalRunsCount)
+	_, _ = fmt.Fprintf(w, "  %s: %.1f%%\n", green("✔ Success"), rss.Rate.SuccesRate*100)
+	_, _ = fmt.Fprintf(w, "  %s: %.1f%%\n", red("✖ Failure"), rss.Rate.FailureRate*100)
+	_, _ = fmt.Fprintf(w, "  %s: %.1f%%\n", yellow("\U0001F914 Others"), rss.Rate.OthersRate*100)
+
+	if flakiest := maxWorkflow(workflows, func(wf *parser.WorkflowStatsSummary) float64 { return wf.Rate.FailureRate }); flakiest != nil {
+		_, _ = fmt.Fprintf(w, "\n%s Highest failure rate: %s (%.1f%%)\n", "\U0001F525", cyan(flakiest.Name), flakiest.Rate.FailureRate*100)
+	}
+	if slowest := maxWorkflow(workflows, func(wf *parser.WorkflowStatsSummary) float64 { return wf.ExecutionDurationStats.Med }); slowest != nil {
+		_, _ = fmt.Fprintf(w, "%s Longest median duration: %s (%.1fs)\n", "\U0001F422", cyan(slowest.Name), slowest.ExecutionDurationStats.Med)
+	}
+}
+
+// maxWorkflow returns the workflow with the largest positive value, or nil if there is none
+func maxWorkflow(workflows []*parser.WorkflowStatsSummary, value func(*parser.WorkflowStatsSummary) float64) *parser.WorkflowStatsSummary {
+	var res *parser.WorkflowStatsSummary
+	for _, wf := range workflows {
+		if value(wf) > 0 && (res == nil || value(wf) > value(res)) {
+			res = wf
+		}
+	}
+	return res
+}
+
+// percentileColumns returns the tab separated headers of the percentile columns of a table, e.g. "\tP95\tP99"
+func percentileColumns(ps []parser.Percentile) string {
+	var b strings.Builder
+	for _, p := range ps {
+		b.WriteString("\t" + strings.ToUpper(p.Label()))
+	}
+	return b.String()
+}
+
+// percentileValues returns the tab separated values of the percentile columns of a table
+func percentileValues(ps []parser.Percentile) string {
+	var b strings.Builder
+	for _, p := range ps {
+		_, _ = fmt.Fprintf(&b, "\t%.1fs", p.Value)
+	}
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	tests := []struct {
+		name  string
+		rss   *parser.RepositoryStatsSummary
+		wantW string
+	}{
+		{
+			name:  "Empty",
+			rss:   &parser.RepositoryStatsSummary{Workflows: []*parser.WorkflowStatsSummary{}},
+			wantW: "🗂 Workflows: 0\n  Workflow  Runs  Success  Failure  Others  Avg  Med  Max\n\n🏃 Repository total runs: 0\n  ✔ Success: 0.0%\n  ✖ Failure: 0.0%\n  🤔 Others: 0.0%\n",
+		},
+		{
+			name: "Sorted by failure rate",
+			rss: &parser.RepositoryStatsSummary{
+				TotalRunsCount: 30,
+				Rate:           parser.Rate{SuccesRate: 0.8, FailureRate: 0.2},
+				Workflows: []*parser.WorkflowStatsSummary{
+					{
+						Name:                   "CI",
+						TotalRunsCount:         20,
+						Rate:                   parser.Rate{SuccesRate: 0.9, FailureRate: 0.1},
+						ExecutionDurationStats: parser.ExecutionDurationStats{Avg: 100, Med: 90, Max: 200},
+					},
+					{
+						Name:                   "E2E",
+						TotalRunsCount:         10,
+						Rate:                   parser.Rate{SuccesRate: 0.6, FailureRate: 0.4},
+						ExecutionDurationStats: parser.ExecutionDurationStats{Avg: 50, Med: 45, Max: 60},
+					},
+				},
+			},
+			wantW: "🗂 Workflows: 2\n" +
+				"  Workflow  Runs  Success  Failure  Others  Avg     Med    Max\n" +
+				"  E2E       10    60.0%    40.0%    0.0%    50.0s   45.0s  60.0s\n" +
+				"  CI        20    90.0%    10.0%    0.0%    100.0s  90.0s  200.0s\n" +
+				"\n🏃 Repository total runs: 30\n  ✔ Success: 80.0%\n  ✖ Failure: 20.0%\n  🤔 Others: 0.0%\n" +
+				"\n🔥 Highest failure rate: E2E (40.0%)\n🐢 Longest median duration: CI (90.0s)\n",
+		},
+		{
+			name: "Percentiles",
+			rss: &parser.RepositoryStatsSummary{
+				TotalRunsCount: 10,
+				Rate:           parser.Rate{SuccesRate: 1},
+				Workflows: []*parser.WorkflowStatsSummary{
+					{
+						Name:           "CI",
+						TotalRunsCount: 10,
+						Rate:           parser.Rate{SuccesRate: 1},
+						ExecutionDurationStats: parser.ExecutionDurationStats{
+							Avg: 100, Med: 90, Max: 200,
+							Percentiles: []parser.Percentile{{P: 95, Value: 180}},
+						},
+					},
+				},
+			},
+			wantW: "🗂 Workflows: 1\n" +
+				"  Workflow  Runs  Success  Failure  Others  Avg     Med    P95     Max\n" +
+				"  CI        10    100.0%   0.0%     0.0%    100.0s  90.0s  180.0s  200.0s\n" +
+				"\n🏃 Repository total runs: 10\n  ✔ Success: 100.0%\n  ✖ Failure: 0.0%\n  🤔 Others: 0.0%\n" +
+				"🐢 Longest median duration: CI (90.0s)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			Repository(w, tt.rss)
+			assert.Equal(t, tt.wantW, w.String())
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+func Retries(w io.Writer, rs *parser.RetrySummary, n int) {
+	_, _ = fmt.Fprintf(w, "%s Runs: %d, attempts: %d (%d extra attempts)\n", "\U0001F501", rs.RunsCount, rs.AttemptsCount, rs.AttemptsCount-rs.RunsCount)
+	_, _ = fmt.Fprintf(w, "  Re-run runs: %d (%.1f%%)\n", rs.RerunRunsCount, rs.RerunRate*100)
+	_, _ = fmt.Fprintf(w, "  Time lost to failed attempts: %s (%d attempts)\n", formatSeconds(rs.TimeLost), rs.FailedAttemptsCount)
+
+	_, _ = fmt.Fprintf(w, "\n%s Attempts per run\n", "\U0001F4CA")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  Attempts\tRuns")
+	for _, a := range rs.Attempts {
+		_, _ = fmt.Fprintf(tw, "  %d\t%d\n", a.Attempts, a.RunsCount)
+	}
+	_ = tw.Flush()
+
+	actorsNum := min(len(rs.Actors), n)
+	_, _ = fmt.Fprintf(w, "\n%s Top %d actors triggering re-runs\n", "\U0001F64B", actorsNum)
+	cyan := color.New(color.FgCyan).SprintFunc()
+	for _, a := range rs.Actors[:actorsNum] {
+		_, _ = fmt.Fprintf(w, "  %s: %d\n", cyan(a.Actor), a.RerunsCount)
+	}
+}
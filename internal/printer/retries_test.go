@@ -0,0 +1,43 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetries(t *testing.T) {
+	rs := &parser.RetrySummary{
+		RunsCount:           4,
+		AttemptsCount:       7,
+		RerunRunsCount:      2,
+		RerunRate:           0.5,
+		FailedAttemptsCount: 3,
+		TimeLost:            3725.4,
+		Attempts: []*parser.AttemptsCount{
+			{Attempts: 1, RunsCount: 2},
+			{Attempts: 2, RunsCount: 1},
+			{Attempts: 3, RunsCount: 1},
+		},
+		Actors: []*parser.RerunActor{
+			{Actor: "alice", RerunsCount: 2},
+			{Actor: "bob", RerunsCount: 1},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	Retries(w, rs, 1)
+
+	assert.Equal(t, "🔁 Runs: 4, attempts: 7 (3 extra attempts)\n"+
+		"  Re-run runs: 2 (50.0%)\n"+
+		"  Time lost to failed attempts: 1h2m5s (3 attempts)\n"+
+		"\n📊 Attempts per run\n"+
+		"  Attempts  Runs\n"+
+		"  1         2\n"+
+		"  2         1\n"+
+		"  3         1\n"+
+		"\n🙋 Top 1 actors triggering re-runs\n"+
+		"  alice: 2\n", w.String())
+}
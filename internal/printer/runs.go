@@ -3,6 +3,7 @@ package printer
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/fchimpan/gh-workflow-stats/internal/parser"
@@ -12,6 +13,9 @@ const (
 	totalRunsFormat     = "%s Total runs: %d\n"
 	conclusionFormat    = "  %s: %d (%.1f%%)\n"
 	executionTimeFormat = "\n%s Workflow run execution time stats\n"
+	jobTimeFormat       = "\n%s Sum of job execution time stats\n"
+	attemptRatesFormat  = "\n%s Success rate by attempt (%d runs, %d attempts)\n"
+	attemptRateFormat   = "  %s: %.1f%%\n"
 	executionFormat     = "  %s: %.1fs\n"
 )
 
@@ -41,10 +45,42 @@ func Runs(w io.Writer, wrs *parser.WorkflowRunsStatsSummary) {
 	_, _ = fmt.Fprintf(w, conclusionFormat, red("\u2716 Failure"), fc, fr)
 	_, _ = fmt.Fprintf(w, conclusionFormat, yellow("\U0001F914 Others"), oc, or)
 
+	if ar := wrs.AttemptRates; ar != nil {
+		_, _ = fmt.Fprintf(w, attemptRatesFormat, "\U0001F501", ar.RunsCount, ar.AttemptsCount)
+		_, _ = fmt.Fprintf(w, attemptRateFormat, "First attempt", ar.FirstAttemptSuccessRate*100)
+		_, _ = fmt.Fprintf(w, attemptRateFormat, "Final", ar.FinalSuccessRate*100)
+		_, _ = fmt.Fprintf(w, attemptRateFormat, "Per attempt", ar.PerAttemptSuccessRate*100)
+	}
+
 	_, _ = fmt.Fprintf(w, executionTimeFormat, "\u23F0")
-	_, _ = fmt.Fprintf(w, executionFormat, "Min", wrs.ExecutionDurationStats.Min)
-	_, _ = fmt.Fprintf(w, executionFormat, "Max", wrs.ExecutionDurationStats.Max)
-	_, _ = fmt.Fprintf(w, executionFormat, "Avg", wrs.ExecutionDurationStats.Avg)
-	_, _ = fmt.Fprintf(w, executionFormat, "Med", wrs.ExecutionDurationStats.Med)
-	_, _ = fmt.Fprintf(w, executionFormat, "Std", wrs.ExecutionDurationStats.Std)
+	executionStats(w, wrs.ExecutionDurationStats)
+
+	if wrs.JobTimeStats != nil {
+		_, _ = fmt.Fprintf(w, jobTimeFormat, "\u2699")
+		executionStats(w, *wrs.JobTimeStats)
+	}
+
+	if wrs.DurationHistogram != nil {
+		durationHistogram(w, wrs.DurationHistogram)
+	}
+}
+
+func executionStats(w io.Writer, s parser.ExecutionDurationStats) {
+	_, _ = fmt.Fprintf(w, executionFormat, "Min", s.Min)
+	_, _ = fmt.Fprintf(w, executionFormat, "Max", s.Max)
+	_, _ = fmt.Fprintf(w, executionFormat, "Avg", s.Avg)
+	_, _ = fmt.Fprintf(w, executionFormat, "Med", s.Med)
+	_, _ = fmt.Fprintf(w, executionFormat, "Std", s.Std)
+	for _, p := range s.Percentiles {
+		_, _ = fmt.Fprintf(w, executionFormat, strings.ToUpper(p.Label()), p.Value)
+	}
+}
+
+// formatPercentiles formats the percentiles as "p90: 1.00s, p95: 2.00s"
+func formatPercentiles(ps []parser.Percentile) string {
+	parts := make([]string, 0, len(ps))
+	for _, p := range ps {
+		parts = append(parts, fmt.Sprintf("%s: %.2fs", p.Label(), p.Value))
+	}
+	return strings.Join(parts, ", ")
 }
@@ -146,3 +146,55 @@ func TestRuns(t *testing.T) {
 		})
 	}
 }
+
+func TestRuns_Percentiles(t *testing.T) {
+	wrs := &parser.WorkflowRunsStatsSummary{
+		TotalRunsCount: 1,
+		Conclusions:    map[string]*parser.WorkflowRunsConclusion{},
+		ExecutionDurationStats: parser.ExecutionDurationStats{
+			Min:         10,
+			Max:         10,
+			Avg:         10,
+			Med:         10,
+			Percentiles: []parser.Percentile{{P: 95, Value: 10}, {P: 99.9, Value: 12.34}},
+		},
+	}
+
+	w := &bytes.Buffer{}
+	Runs(w, wrs)
+
+	assert.Contains(t, w.String(), "  Std: 0.0s\n  P95: 10.0s\n  P99.9: 12.3s\n")
+}
+
+func TestRuns_JobTime(t *testing.T) {
+	wrs := &parser.WorkflowRunsStatsSummary{
+		TotalRunsCount:         1,
+		Conclusions:            map[string]*parser.WorkflowRunsConclusion{},
+		ExecutionDurationStats: parser.ExecutionDurationStats{Min: 10, Max: 10, Avg: 10, Med: 10},
+		JobTimeStats:           &parser.ExecutionDurationStats{Min: 25, Max: 25, Avg: 25, Med: 25},
+	}
+
+	w := &bytes.Buffer{}
+	Runs(w, wrs)
+
+	assert.Contains(t, w.String(), "  Std: 0.0s\n\n⚙ Sum of job execution time stats\n  Min: 25.0s\n  Max: 25.0s\n  Avg: 25.0s\n  Med: 25.0s\n  Std: 0.0s\n")
+}
+
+func TestRuns_AttemptRates(t *testing.T) {
+	wrs := &parser.WorkflowRunsStatsSummary{
+		TotalRunsCount: 4,
+		Conclusions:    map[string]*parser.WorkflowRunsConclusion{},
+		AttemptRates: &parser.AttemptRates{
+			RunsCount:               4,
+			AttemptsCount:           6,
+			FirstAttemptSuccessRate: 0.25,
+			FinalSuccessRate:        0.5,
+			PerAttemptSuccessRate:   1.0 / 3,
+		},
+	}
+
+	w := &bytes.Buffer{}
+	Runs(w, wrs)
+
+	assert.Contains(t, w.String(), "\n🔁 Success rate by attempt (4 runs, 6 attempts)\n  First attempt: 25.0%\n  Final: 50.0%\n  Per attempt: 33.3%\n\n⏰")
+}
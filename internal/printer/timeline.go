@@ -0,0 +1,204 @@
+package printer
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+// timelineWidth is the number of characters of the timeline bars
+const timelineWidth = 60
+
+func Timeline(w io.Writer, tl *parser.RunTimeline) {
+	_, _ = fmt.Fprintf(w, "%s Timeline of %s\n", "\U0001F5D3", timelineTitle(tl))
+	_, _ = fmt.Fprintf(w, "  %s\n", tl.HTMLURL)
+	if len(tl.Jobs) == 0 {
+		_, _ = fmt.Fprintln(w, "  No jobs found")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "  Job\t%s\tQueued\tExecution\tConclusion\n", timelineAxis(tl.Duration, timelineWidth))
+	for _, j := range tl.Jobs {
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n",
+			j.Name,
+			timelineBar(j, tl.Duration, timelineWidth),
+			formatSeconds(j.QueueDuration),
+			formatSeconds(j.ExecutionDuration),
+			orStatus(j.Conclusion, j.Status),
+		)
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintln(w, "\n  . queued  = running  | step boundary")
+}
+
+func timelineTitle(tl *parser.RunTimeline) string {
+	return fmt.Sprintf("run %d attempt %d (%s): %s in %s", tl.RunID, tl.RunAttempt, tl.Name, orStatus(tl.Conclusion, tl.Status), formatSeconds(tl.Duration))
+}
+
+// timelineAxis labels the start and the end of the timeline
+func timelineAxis(total float64, width int) string {
+	start, end := "0s", formatSeconds(total)
+	return start + strings.Repeat(" ", max(width-len(start)-len(end), 1)) + end
+}
+
+// timelineBar draws the queue time, the execution time and the step boundaries of a job on a bar of the given width
+func timelineBar(j *parser.JobTimeline, total float64, width int) string {
+	scale := 0.0
+	if total > 0 {
+		scale = float64(width) / total
+	}
+	col := func(s float64) int {
+		return min(int(s*scale), width)
+	}
+
+	queued, started, completed := col(j.QueuedOffset), col(j.StartedOffset), col(j.CompletedOffset)
+	if j.ExecutionDuration > 0 && started == completed {
+		// Keep short jobs visible
+		if completed < width {
+			completed++
+		} else {
+			started--
+		}
+	}
+
+	bar := []byte(strings.Repeat(" ", width))
+	for i := queued; i < started; i++ {
+		bar[i] = '.'
+	}
+	for i := started; i < completed; i++ {
+		bar[i] = '='
+	}
+	for _, s := range j.Steps {
+		if p := col(s.StartedOffset); p > started && p < completed {
+			bar[p] = '|'
+		}
+	}
+	return string(bar)
+}
+
+func orStatus(conclusion, status string) string {
+	if conclusion == "" {
+		return status
+	}
+	return conclusion
+}
+
+//go:embed templates/timeline.svg.tmpl
+var timelineTemplate string
+
+var timelineTmpl = template.Must(template.New("timeline").Parse(timelineTemplate))
+
+// timelineChart is the geometry of the SVG timeline
+var timelineChart = struct {
+	Width, Left, Right, Top, RowHeight, BarHeight float64
+	Ticks                                         int
+}{
+	Width:     1000,
+	Left:      230,
+	Right:     980,
+	Top:       48,
+	RowHeight: 22,
+	BarHeight: 14,
+	Ticks:     4,
+}
+
+type svgTimeline struct {
+	Title         string
+	URL           string
+	Width, Height float64
+	AxisY         float64
+	AxisBottom    float64
+	BarHeight     float64
+	Ticks         []svgTimelineTick
+	Rows          []svgTimelineRow
+}
+
+type svgTimelineTick struct {
+	X     string
+	Label string
+}
+
+type svgTimelineRow struct {
+	Name       string
+	Tooltip    string
+	TextY      string
+	BarY       string
+	BarEnd     string
+	QueueX     string
+	QueueWidth string
+	ExecX      string
+	ExecWidth  string
+	Color      string
+	Boundaries []string
+}
+
+// TimelineSVG renders the timeline of a run as an SVG image
+func TimelineSVG(w io.Writer, tl *parser.RunTimeline) error {
+	c := timelineChart
+	scale := 0.0
+	if tl.Duration > 0 {
+		scale = (c.Right - c.Left) / tl.Duration
+	}
+	x := func(s float64) float64 {
+		return c.Left + s*scale
+	}
+
+	data := svgTimeline{
+		Title:      "Timeline of " + timelineTitle(tl),
+		URL:        tl.HTMLURL,
+		Width:      c.Width,
+		Height:     c.Top + float64(len(tl.Jobs))*c.RowHeight + 16,
+		AxisY:      c.Top - 8,
+		AxisBottom: c.Top + float64(len(tl.Jobs))*c.RowHeight,
+		BarHeight:  c.BarHeight,
+	}
+	for i := 0; i <= c.Ticks; i++ {
+		s := tl.Duration * float64(i) / float64(c.Ticks)
+		data.Ticks = append(data.Ticks, svgTimelineTick{X: svgNum(x(s)), Label: formatSeconds(s)})
+	}
+	for i, j := range tl.Jobs {
+		y := c.Top + float64(i)*c.RowHeight
+		row := svgTimelineRow{
+			Name: j.Name,
+			Tooltip: fmt.Sprintf("%s: %s, queued %s, ran %s",
+				j.Name, orStatus(j.Conclusion, j.Status), formatSeconds(j.QueueDuration), formatSeconds(j.ExecutionDuration)),
+			TextY:      svgNum(y + c.BarHeight - 3),
+			BarY:       svgNum(y),
+			BarEnd:     svgNum(y + c.BarHeight),
+			QueueX:     svgNum(x(j.QueuedOffset)),
+			QueueWidth: svgNum(j.QueueDuration * scale),
+			ExecX:      svgNum(x(j.StartedOffset)),
+			ExecWidth:  svgNum(max(j.ExecutionDuration*scale, 1)),
+			Color:      conclusionColor(j.Conclusion),
+		}
+		for k, s := range j.Steps {
+			if k == 0 {
+				continue
+			}
+			row.Boundaries = append(row.Boundaries, svgNum(x(s.StartedOffset)))
+		}
+		data.Rows = append(data.Rows, row)
+	}
+	return timelineTmpl.Execute(w, data)
+}
+
+func conclusionColor(conclusion string) string {
+	switch conclusion {
+	case parser.ConclusionSuccess:
+		return "#2da44e"
+	case parser.ConclusionFailure:
+		return "#cf222e"
+	default:
+		return "#8c959f"
+	}
+}
+
+func svgNum(f float64) string {
+	return fmt.Sprintf("%.1f", f)
+}
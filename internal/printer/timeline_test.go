@@ -0,0 +1,69 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTimeline() *parser.RunTimeline {
+	return &parser.RunTimeline{
+		RunID:      1,
+		RunAttempt: 2,
+		Name:       "CI",
+		Status:     parser.StatusCompleted,
+		Conclusion: parser.ConclusionFailure,
+		HTMLURL:    "https://github.com/owner/repo/actions/runs/1/attempts/2",
+		Duration:   600,
+		Jobs: []*parser.JobTimeline{
+			{Name: "build", Status: parser.StatusCompleted, Conclusion: parser.ConclusionSuccess, QueuedOffset: 0, StartedOffset: 60, CompletedOffset: 300, QueueDuration: 60, ExecutionDuration: 240, Steps: []*parser.StepTimeline{
+				{Name: "checkout", Number: 1, StartedOffset: 60, CompletedOffset: 120},
+				{Name: "build", Number: 2, StartedOffset: 120, CompletedOffset: 300},
+			}},
+			{Name: "<test>", Status: parser.StatusCompleted, Conclusion: parser.ConclusionFailure, QueuedOffset: 300, StartedOffset: 360, CompletedOffset: 600, QueueDuration: 60, ExecutionDuration: 240},
+			{Name: "lint", Status: "in_progress", QueuedOffset: 0, StartedOffset: 0, CompletedOffset: 1, ExecutionDuration: 1},
+		},
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	w := &bytes.Buffer{}
+	Timeline(w, newTestTimeline())
+
+	assert.Equal(t, "🗓 Timeline of run 1 attempt 2 (CI): failure in 10m0s\n"+
+		"  https://github.com/owner/repo/actions/runs/1/attempts/2\n"+
+		"  Job     0s                                                     10m0s  Queued  Execution  Conclusion\n"+
+		"  build   ......======|=================                                1m0s    4m0s       success\n"+
+		"  <test>                                ......========================  1m0s    4m0s       failure\n"+
+		"  lint    =                                                             0s      1s         in_progress\n"+
+		"\n  . queued  = running  | step boundary\n", w.String())
+}
+
+func TestTimeline_NoJobs(t *testing.T) {
+	w := &bytes.Buffer{}
+	Timeline(w, &parser.RunTimeline{RunID: 1, RunAttempt: 1, Name: "CI", Status: "queued", HTMLURL: "https://github.com/owner/repo/actions/runs/1/attempts/1"})
+
+	assert.Equal(t, "🗓 Timeline of run 1 attempt 1 (CI): queued in 0s\n"+
+		"  https://github.com/owner/repo/actions/runs/1/attempts/1\n"+
+		"  No jobs found\n", w.String())
+}
+
+func TestTimelineSVG(t *testing.T) {
+	w := &bytes.Buffer{}
+	require.NoError(t, TimelineSVG(w, newTestTimeline()))
+
+	svg := w.String()
+	assert.Contains(t, svg, `<svg xmlns="http://www.w3.org/2000/svg" width="1000" height="130"`)
+	assert.Contains(t, svg, `<a href="https://github.com/owner/repo/actions/runs/1/attempts/2">`)
+	assert.Contains(t, svg, `<title>build: success, queued 1m0s, ran 4m0s</title>`)
+	assert.Contains(t, svg, `<rect x="230.0" y="48.0" width="75.0" height="14" fill="#d0d7de"/>`)
+	assert.Contains(t, svg, `<rect x="305.0" y="48.0" width="300.0" height="14" fill="#2da44e"/>`)
+	assert.Contains(t, svg, `<line x1="380.0" y1="48.0" x2="380.0" y2="62.0" stroke="#ffffff"/>`)
+	assert.Contains(t, svg, `fill="#cf222e"`)
+	assert.Contains(t, svg, `&lt;test&gt;`)
+	assert.NotContains(t, svg, `<test>`)
+	assert.Contains(t, svg, `>10m0s</text>`)
+}
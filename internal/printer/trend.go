@@ -0,0 +1,29 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+)
+
+func Trend(w io.Writer, ts *parser.WorkflowRunsTrendSummary) {
+	_, _ = fmt.Fprintf(w, "%s Workflow runs per %s (%s)\n", "\U0001F4C5", ts.Period, ts.TimeZone)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  Period\tRuns\tSuccess\tFailure\tOthers\tMin\tMed\tP95")
+	for _, b := range ts.Buckets {
+		_, _ = fmt.Fprintf(tw, "  %s\t%d\t%.1f%%\t%.1f%%\t%.1f%%\t%.1fs\t%.1fs\t%.1fs\n",
+			b.Start.Format("2006-01-02"),
+			b.TotalRunsCount,
+			b.Rate.SuccesRate*100,
+			b.Rate.FailureRate*100,
+			b.Rate.OthersRate*100,
+			b.ExecutionDurationStats.Min,
+			b.ExecutionDurationStats.Med,
+			b.ExecutionDurationStats.P95,
+		)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,54 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fchimpan/gh-workflow-stats/internal/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrend(t *testing.T) {
+	tests := []struct {
+		name  string
+		ts    *parser.WorkflowRunsTrendSummary
+		wantW string
+	}{
+		{
+			name:  "Empty",
+			ts:    &parser.WorkflowRunsTrendSummary{Period: parser.PeriodDay, TimeZone: "UTC", Buckets: []*parser.TrendBucket{}},
+			wantW: "📅 Workflow runs per day (UTC)\n  Period  Runs  Success  Failure  Others  Min  Med  P95\n",
+		},
+		{
+			name: "Weeks",
+			ts: &parser.WorkflowRunsTrendSummary{
+				Period:   parser.PeriodWeek,
+				TimeZone: "Asia/Tokyo",
+				Buckets: []*parser.TrendBucket{
+					{
+						Start:                  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+						TotalRunsCount:         4,
+						Rate:                   parser.Rate{SuccesRate: 0.75, FailureRate: 0.25},
+						ExecutionDurationStats: parser.TrendDurationStats{Min: 10, Med: 20, P95: 29},
+					},
+					{
+						Start: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+			wantW: "📅 Workflow runs per week (Asia/Tokyo)\n" +
+				"  Period      Runs  Success  Failure  Others  Min    Med    P95\n" +
+				"  2024-01-01  4     75.0%    25.0%    0.0%    10.0s  20.0s  29.0s\n" +
+				"  2024-01-08  0     0.0%     0.0%     0.0%    0.0s   0.0s   0.0s\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			Trend(w, tt.ts)
+			assert.Equal(t, tt.wantW, w.String())
+		})
+	}
+}
@@ -44,10 +44,39 @@ type OutputOptions struct {
 type OutputFormat string
 
 const (
-	OutputFormatText OutputFormat = "text"
-	OutputFormatJSON OutputFormat = "json"
+	OutputFormatText        OutputFormat = "text"
+	OutputFormatJSON        OutputFormat = "json"
+	OutputFormatMarkdown    OutputFormat = "markdown"
+	OutputFormatHTML        OutputFormat = "html"
+	OutputFormatOpenMetrics OutputFormat = "openmetrics"
+	OutputFormatCSV         OutputFormat = "csv"
+	OutputFormatTSV         OutputFormat = "tsv"
 )
 
+// IsTabular returns true if the format emits a single flat table
+func (f OutputFormat) IsTabular() bool {
+	return f == OutputFormatCSV || f == OutputFormatTSV
+}
+
+// OutputTable represents the table emitted by tabular output formats
+type OutputTable string
+
+const (
+	OutputTableRuns  OutputTable = "runs"
+	OutputTableJobs  OutputTable = "jobs"
+	OutputTableSteps OutputTable = "steps"
+)
+
+// IsValid returns true if the table is supported
+func (t OutputTable) IsValid() bool {
+	switch t {
+	case OutputTableRuns, OutputTableJobs, OutputTableSteps:
+		return true
+	default:
+		return false
+	}
+}
+
 // APIRequestOptions represents options for GitHub API requests
 type APIRequestOptions struct {
 	Actor               string `json:"actor,omitempty"`
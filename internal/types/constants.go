@@ -19,6 +19,9 @@ const (
 	DefaultRetryAttempts = 3
 	DefaultRetryDelay    = time.Second * 5
 
+	// LogErrorMarker prefixes the error lines of a job log
+	LogErrorMarker = "##[error]"
+
 	// File extensions
 	WorkflowFileExtension = ".yml"
 	YAMLFileExtension     = ".yaml"